@@ -0,0 +1,11 @@
+data "dvls_entry_certificate" "example" {
+  id = "00000000-0000-0000-0000-000000000000"
+}
+
+locals {
+  certificate = provider::dvls::certificate_info(data.dvls_entry_certificate.example.file.content_b64, data.dvls_entry_certificate.example.password)
+}
+
+output "certificate_not_after" {
+  value = local.certificate.not_after
+}
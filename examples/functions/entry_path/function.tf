@@ -0,0 +1,4 @@
+output "entry_path" {
+  # vault\foo\bar\name
+  value = provider::dvls::entry_path("vault", "foo\\bar", "name")
+}
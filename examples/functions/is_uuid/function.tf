@@ -0,0 +1,8 @@
+variable "vault_id" {
+  type = string
+
+  validation {
+    condition     = provider::dvls::is_uuid(var.vault_id)
+    error_message = "vault_id must be a UUID."
+  }
+}
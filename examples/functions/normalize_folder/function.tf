@@ -0,0 +1,8 @@
+resource "dvls_entry_user_credential" "example" {
+  vault_id = "00000000-0000-0000-0000-000000000000"
+  name     = "foo"
+  username = "foo"
+  password = "bar"
+  # foo\bar
+  folder = provider::dvls::normalize_folder("\\foo\\ bar\\")
+}
@@ -0,0 +1,8 @@
+locals {
+  entry = provider::dvls::parse_entry_path("vault\\foo\\bar\\name")
+}
+
+output "entry_folder" {
+  # foo\bar
+  value = local.entry.folder
+}
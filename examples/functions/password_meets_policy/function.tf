@@ -0,0 +1,30 @@
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+locals {
+  password_policy = {
+    min_length           = 16
+    max_length           = null
+    min_lowercase        = 1
+    min_uppercase        = 1
+    min_digits           = 1
+    min_symbols          = 1
+    forbidden_substrings = ["password", "devolutions"]
+  }
+}
+
+resource "dvls_entry_user_credential" "example" {
+  vault_id = "00000000-0000-0000-0000-000000000000"
+  name     = "foo"
+  username = "foo"
+  password = var.password
+
+  lifecycle {
+    precondition {
+      condition     = provider::dvls::password_meets_policy(var.password, local.password_policy)
+      error_message = "password does not meet the password policy."
+    }
+  }
+}
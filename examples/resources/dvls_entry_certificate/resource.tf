@@ -29,3 +29,21 @@ resource "dvls_entry_certificate" "file" {
     content_b64 = filebase64("test.p12")
   }
 }
+
+# Example with PEM content
+resource "dvls_entry_certificate" "pem" {
+  vault_id    = "00000000-0000-0000-0000-000000000000"
+  name        = "foo"
+  description = "bar"
+  password    = "bar"
+  folder      = "foo\\bar"
+  expiration  = "2022-12-31T23:59:59-05:00"
+  tags        = ["foo", "bar"]
+
+  pem = {
+    name        = "test.p12"
+    certificate = file("cert.pem")
+    chain       = file("chain.pem")
+    private_key = file("key.pem")
+  }
+}
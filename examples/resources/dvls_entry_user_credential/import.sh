@@ -1 +1,4 @@
 terraform import dvls_entry_user_credential.example 00000000-0000-0000-0000-000000000000
+
+# Import with the vault ID, to ensure the entry belongs to the expected vault
+terraform import dvls_entry_user_credential.example 00000000-0000-0000-0000-000000000000/00000000-0000-0000-0000-000000000000
@@ -7,3 +7,18 @@ resource "dvls_entry_user_credential" "example" {
   folder      = "foo\\bar"
   tags        = ["foo", "bar"]
 }
+
+# Generate the password locally instead of setting it
+resource "dvls_entry_user_credential" "generated" {
+  vault_id = "00000000-0000-0000-0000-000000000000"
+  name     = "generated"
+  username = "foo"
+
+  password_template = {
+    min_length           = 24
+    min_uppercase        = 1
+    min_digits           = 1
+    min_symbols          = 1
+    forbidden_substrings = ["foo"]
+  }
+}
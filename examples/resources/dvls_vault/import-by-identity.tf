@@ -0,0 +1,6 @@
+import {
+  to = dvls_vault.example
+  identity = {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
@@ -5,3 +5,11 @@ resource "dvls_vault" "example" {
   visibility      = "private"
   master_password = "foo!"
 }
+
+# Example with a write-only master password (Terraform 1.11 or later)
+resource "dvls_vault" "write_only" {
+  name                       = "foo"
+  security_level             = "high"
+  master_password_wo         = "foo!"
+  master_password_wo_version = 1
+}
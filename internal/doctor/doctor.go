@@ -0,0 +1,216 @@
+// Package doctor implements the doctor subcommand, which diagnoses the connection to a DVLS instance
+// with the same credentials as the provider.
+package doctor
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Devolutions/go-dvls"
+)
+
+// maxClockSkew is the clock difference with the DVLS server above which a warning is reported.
+const maxClockSkew = 5 * time.Minute
+
+type vaultIds []string
+
+func (v *vaultIds) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *vaultIds) Set(value string) error {
+	*v = append(*v, value)
+	return nil
+}
+
+type report struct {
+	w      io.Writer
+	failed bool
+}
+
+func (r *report) ok(format string, a ...any) {
+	fmt.Fprintf(r.w, "[ OK ] "+format+"\n", a...)
+}
+
+func (r *report) info(format string, a ...any) {
+	fmt.Fprintf(r.w, "       "+format+"\n", a...)
+}
+
+func (r *report) warn(format string, a ...any) {
+	fmt.Fprintf(r.w, "[WARN] "+format+"\n", a...)
+}
+
+func (r *report) fail(format string, a ...any) {
+	r.failed = true
+	fmt.Fprintf(r.w, "[FAIL] "+format+"\n", a...)
+}
+
+// Run runs the doctor subcommand with args and writes its report to w. It reads the DVLS_BASE_URI,
+// DVLS_APP_ID and DVLS_APP_SECRET environment variables and returns an error if any check failed.
+func Run(args []string, w io.Writer) error {
+	var vaults vaultIds
+	var timeout time.Duration
+
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	flags.SetOutput(w)
+	flags.Var(&vaults, "vault", "ID of a vault to check access to, can be repeated")
+	flags.DurationVar(&timeout, "timeout", 10*time.Second, "timeout of the network checks")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	r := &report{w: w}
+
+	baseUri := os.Getenv("DVLS_BASE_URI")
+	appId := os.Getenv("DVLS_APP_ID")
+	appSecret := os.Getenv("DVLS_APP_SECRET")
+
+	for _, env := range []struct{ name, value string }{
+		{"DVLS_BASE_URI", baseUri},
+		{"DVLS_APP_ID", appId},
+		{"DVLS_APP_SECRET", appSecret},
+	} {
+		if env.value == "" {
+			r.fail("%s is not set", env.name)
+		}
+	}
+
+	if r.failed {
+		return errors.New("missing configuration")
+	}
+
+	u, err := url.Parse(baseUri)
+	if err != nil || u.Host == "" {
+		r.fail("DVLS_BASE_URI %q is not a valid URL", baseUri)
+		return errors.New("invalid configuration")
+	}
+
+	r.ok("using %s with app id %s", baseUri, appId)
+
+	if u.Scheme == "https" {
+		checkTLS(r, u, timeout)
+	} else {
+		r.warn("%s does not use HTTPS, credentials are sent in clear text", baseUri)
+	}
+
+	checkClockSkew(r, &http.Client{Timeout: timeout}, baseUri)
+
+	client, err := dvls.NewClient(appId, appSecret, baseUri)
+	if err != nil {
+		r.fail("unable to authenticate: %s", err)
+		return errors.New("one or more checks failed")
+	}
+
+	r.ok("authenticated as %s", client.ClientUser.Username)
+
+	server, err := client.GetPrivateServerInfo()
+	if err != nil {
+		r.fail("unable to read server information: %s", err)
+	} else {
+		r.ok("server %s, version %s", server.ServerName, server.Version)
+	}
+
+	checkVaults(r, &client, vaults)
+
+	if r.failed {
+		return errors.New("one or more checks failed")
+	}
+
+	return nil
+}
+
+func checkTLS(r *report, u *url.URL, timeout time.Duration) {
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: u.Hostname()})
+	if err != nil {
+		r.fail("TLS handshake with %s failed: %s", address, err)
+
+		// Connect again without verification to show the chain presented by the server.
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: u.Hostname(), InsecureSkipVerify: true})
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reportChain(r, conn.ConnectionState().PeerCertificates)
+		return
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	r.ok("TLS handshake with %s succeeded (%s)", address, tls.VersionName(state.Version))
+	reportChain(r, state.PeerCertificates)
+}
+
+func reportChain(r *report, certificates []*x509.Certificate) {
+	for i, certificate := range certificates {
+		r.info("%d: %s, issued by %s, expires %s", i, certificate.Subject, certificate.Issuer, certificate.NotAfter.Format(time.RFC3339))
+	}
+
+	if len(certificates) > 0 && time.Until(certificates[0].NotAfter) < 30*24*time.Hour {
+		r.warn("server certificate expires on %s", certificates[0].NotAfter.Format(time.RFC3339))
+	}
+}
+
+// checkClockSkew compares the local clock with the Date header returned by the DVLS server.
+func checkClockSkew(r *report, client *http.Client, baseUri string) {
+	start := time.Now()
+
+	resp, err := client.Head(baseUri)
+	if err != nil {
+		r.fail("unable to reach %s: %s", baseUri, err)
+		return
+	}
+	resp.Body.Close()
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		r.warn("unable to check clock skew, the server did not return a valid Date header")
+		return
+	}
+
+	// The Date header has a one second resolution, measure against the middle of the request.
+	local := start.Add(time.Since(start) / 2)
+	skew := local.Sub(date).Truncate(time.Second)
+
+	if skew.Abs() > maxClockSkew {
+		r.warn("local clock is %s off the server clock, authentication tokens may be rejected", skew)
+		return
+	}
+
+	r.ok("clock skew with the server is %s", skew)
+}
+
+func checkVaults(r *report, client *dvls.Client, vaults vaultIds) {
+	if len(vaults) == 0 {
+		r.warn("no -vault given, vault access was not checked (vaults cannot be listed)")
+		return
+	}
+
+	for _, vaultId := range vaults {
+		vault, err := client.Vaults.Get(vaultId)
+		if err != nil {
+			r.fail("unable to read vault %s: %s", vaultId, err)
+			continue
+		}
+
+		r.ok("vault %s (%s) can be read", vault.Name, vault.ID)
+	}
+}
@@ -0,0 +1,53 @@
+package doctor
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunMissingConfiguration(t *testing.T) {
+	t.Setenv("DVLS_BASE_URI", "https://dvls.test/")
+	t.Setenv("DVLS_APP_ID", "")
+	t.Setenv("DVLS_APP_SECRET", "")
+
+	var out bytes.Buffer
+
+	if err := Run(nil, &out); err == nil {
+		t.Fatal("expected an error for missing configuration")
+	}
+
+	for _, name := range []string{"DVLS_APP_ID", "DVLS_APP_SECRET"} {
+		if !strings.Contains(out.String(), "[FAIL] "+name+" is not set") {
+			t.Errorf("expected %s to be reported, got:\n%s", name, out.String())
+		}
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	serverTime := time.Now()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	r := &report{w: &out}
+
+	checkClockSkew(r, server.Client(), server.URL)
+	if !strings.HasPrefix(out.String(), "[ OK ]") {
+		t.Errorf("expected no clock skew, got: %s", out.String())
+	}
+
+	out.Reset()
+	serverTime = time.Now().Add(-time.Hour)
+
+	checkClockSkew(r, server.Client(), server.URL)
+	if !strings.HasPrefix(out.String(), "[WARN]") {
+		t.Errorf("expected a clock skew warning, got: %s", out.String())
+	}
+}
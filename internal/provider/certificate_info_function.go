@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &CertificateInfoFunction{}
+
+func NewCertificateInfoFunction() function.Function {
+	return &CertificateInfoFunction{}
+}
+
+// CertificateInfoFunction defines the function implementation.
+type CertificateInfoFunction struct{}
+
+// CertificateInfoFunctionModel describes the function result.
+type CertificateInfoFunctionModel struct {
+	Subject          string   `tfsdk:"subject"`
+	Issuer           string   `tfsdk:"issuer"`
+	Sans             []string `tfsdk:"sans"`
+	Serial           string   `tfsdk:"serial"`
+	Sha1Thumbprint   string   `tfsdk:"sha1_thumbprint"`
+	Sha256Thumbprint string   `tfsdk:"sha256_thumbprint"`
+	NotBefore        string   `tfsdk:"not_before"`
+	NotAfter         string   `tfsdk:"not_after"`
+}
+
+func (f *CertificateInfoFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "certificate_info"
+}
+
+func (f *CertificateInfoFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Decode certificate metadata",
+		Description: "Decodes a base64 encoded PKCS#12 archive, PEM or DER certificate and returns the metadata of its leaf certificate. Dates are in RFC3339 format and thumbprints and serial are upper case hexadecimal.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "content_b64",
+				Description: "Base64 encoded certificate content, ex.: the content_b64 of a dvls_entry_certificate",
+			},
+			function.StringParameter{
+				Name:           "password",
+				Description:    "PKCS#12 archive password, null or empty for PEM and DER certificates",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"subject":           types.StringType,
+				"issuer":            types.StringType,
+				"sans":              types.ListType{ElemType: types.StringType},
+				"serial":            types.StringType,
+				"sha1_thumbprint":   types.StringType,
+				"sha256_thumbprint": types.StringType,
+				"not_before":        types.StringType,
+				"not_after":         types.StringType,
+			},
+		},
+	}
+}
+
+func (f *CertificateInfoFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var contentB64 string
+	var password types.String
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &contentB64, &password))
+	if resp.Error != nil {
+		return
+	}
+
+	content, err := base64.StdEncoding.DecodeString(contentB64)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("content_b64 is not valid base64. error: %s", err))
+		return
+	}
+
+	certificate, err := parseCertificateContent(content, password.ValueString())
+	if err != nil {
+		if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+			resp.Error = function.NewArgumentFuncError(1, "password does not decrypt the PKCS#12 archive")
+			return
+		}
+
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, newCertificateInfoFunctionModel(certificate)))
+}
+
+func newCertificateInfoFunctionModel(certificate *x509.Certificate) CertificateInfoFunctionModel {
+	sans := []string{}
+	sans = append(sans, certificate.DNSNames...)
+	sans = append(sans, certificate.EmailAddresses...)
+
+	for _, ip := range certificate.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	for _, uri := range certificate.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	sha1Thumbprint := sha1.Sum(certificate.Raw)
+	sha256Thumbprint := sha256.Sum256(certificate.Raw)
+
+	return CertificateInfoFunctionModel{
+		Subject:          certificate.Subject.String(),
+		Issuer:           certificate.Issuer.String(),
+		Sans:             sans,
+		Serial:           strings.ToUpper(certificate.SerialNumber.Text(16)),
+		Sha1Thumbprint:   fmt.Sprintf("%X", sha1Thumbprint),
+		Sha256Thumbprint: fmt.Sprintf("%X", sha256Thumbprint),
+		NotBefore:        certificate.NotBefore.Format(time.RFC3339),
+		NotAfter:         certificate.NotAfter.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestCertificateInfoFunction(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	certificatePEM, privateKeyPEM := testCertificatePEM(t, notAfter)
+
+	content, err := newPKCS12FromPEM(certificatePEM, "", privateKeyPEM, "password")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := map[string]struct {
+		content  string
+		password types.String
+		argument int64
+	}{
+		"pkcs12":         {content: base64.StdEncoding.EncodeToString(content), password: types.StringValue("password"), argument: -1},
+		"pem":            {content: base64.StdEncoding.EncodeToString([]byte(certificatePEM)), password: types.StringNull(), argument: -1},
+		"pem with key":   {content: base64.StdEncoding.EncodeToString([]byte(certificatePEM + privateKeyPEM)), password: types.StringNull(), argument: -1},
+		"wrong password": {content: base64.StdEncoding.EncodeToString(content), password: types.StringValue("wrong"), argument: 1},
+		"invalid base64": {content: "not base64", password: types.StringNull(), argument: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := runCertificateInfoFunction(t, test.content, test.password)
+
+			if test.argument >= 0 {
+				if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != test.argument {
+					t.Fatalf("expected an error on argument %d, got %v", test.argument, resp.Error)
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			result, ok := resp.Result.Value().(types.Object)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+
+			var info CertificateInfoFunctionModel
+			diags := result.As(context.Background(), &info, basetypes.ObjectAsOptions{})
+			if diags.HasError() {
+				t.Fatalf("unable to read result: %v", diags)
+			}
+
+			if info.Subject != "CN=dvls.test" || len(info.Sans) != 1 || info.Sans[0] != "dvls.test" {
+				t.Errorf("unexpected subject %q or sans %v", info.Subject, info.Sans)
+			}
+
+			if info.NotAfter != notAfter.UTC().Format(time.RFC3339) {
+				t.Errorf("unexpected not_after %s", info.NotAfter)
+			}
+		})
+	}
+}
+
+func runCertificateInfoFunction(t *testing.T, content string, password types.String) *function.RunResponse {
+	t.Helper()
+
+	f := NewCertificateInfoFunction()
+
+	var definition function.DefinitionResponse
+	f.Definition(context.Background(), function.DefinitionRequest{}, &definition)
+
+	returnType, ok := definition.Definition.Return.(function.ObjectReturn)
+	if !ok {
+		t.Fatalf("unexpected return type %T", definition.Definition.Return)
+	}
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(returnType.AttributeTypes)),
+	}
+
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(content), password}),
+	}, resp)
+
+	return resp
+}
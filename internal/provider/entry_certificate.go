@@ -35,6 +35,8 @@ func newEntryCertificateFromResourceModel(plans *EntryCertificateResourceModelDa
 
 	if !plans.Data.File.IsNull() {
 		entrycertificate.CertificateIdentifier = plans.File.Name.ValueString()
+	} else if !plans.Data.Pem.IsNull() {
+		entrycertificate.CertificateIdentifier = plans.Pem.Name.ValueString()
 	} else if !plans.Data.Url.IsNull() {
 		entrycertificate.CertificateIdentifier = plans.Url.Url.ValueString()
 		entrycertificate.UseDefaultCredentials = plans.Url.UseDefaultCredentials.ValueBool()
@@ -52,16 +54,33 @@ func setEntryCertificateResourceModel(ctx context.Context, entrycertificate dvls
 	}
 
 	model := EntryCertificateResourceModel{
-		Id:         basetypes.NewStringValue(entrycertificate.ID),
-		VaultId:    basetypes.NewStringValue(entrycertificate.VaultId),
-		Name:       basetypes.NewStringValue(entrycertificate.Name),
-		Expiration: timeVal,
-		Url:        basetypes.NewObjectNull(EntryCertificateResourceModelUrl{}.AttributeTypes()),
-		File:       basetypes.NewObjectNull(EntryCertificateResourceModelFile{}.AttributeTypes()),
+		Id:           basetypes.NewStringValue(entrycertificate.ID),
+		VaultId:      basetypes.NewStringValue(entrycertificate.VaultId),
+		Name:         basetypes.NewStringValue(entrycertificate.Name),
+		Expiration:   timeVal,
+		AllowExpired: data.AllowExpired,
+		Url:          basetypes.NewObjectNull(EntryCertificateResourceModelUrl{}.AttributeTypes()),
+		File:         basetypes.NewObjectNull(EntryCertificateResourceModelFile{}.AttributeTypes()),
+		Pem:          basetypes.NewObjectNull(EntryCertificateResourceModelPem{}.AttributeTypes()),
+	}
+
+	if model.AllowExpired.IsNull() {
+		model.AllowExpired = basetypes.NewBoolValue(false)
+	}
+
+	model.DeletionProtection = data.DeletionProtection
+	if model.DeletionProtection.IsNull() {
+		model.DeletionProtection = basetypes.NewBoolValue(false)
 	}
 
 	switch entrycertificate.GetDataMode() {
 	case dvls.EntryCertificateDataModeFile:
+		// A PEM certificate is stored as a PKCS#12 file, keep the PEM input since it cannot be rebuilt from the file.
+		if !data.Pem.IsNull() {
+			model.Pem = data.Pem
+			break
+		}
+
 		fileObject := EntryCertificateResourceModelFile{
 			ContentB64: basetypes.NewStringValue(base64.StdEncoding.EncodeToString(content)),
 			Name:       basetypes.NewStringValue(entrycertificate.CertificateIdentifier),
@@ -202,6 +221,7 @@ func getPlans(ctx context.Context, plan planInterface) (EntryCertificateResource
 	var model *EntryCertificateResourceModel
 	var urlPlan *EntryCertificateResourceModelUrl
 	var filePlan *EntryCertificateResourceModelFile
+	var pemPlan *EntryCertificateResourceModelPem
 
 	diags.Append(plan.Get(ctx, &model)...)
 	if diags.HasError() {
@@ -218,14 +238,20 @@ func getPlans(ctx context.Context, plan planInterface) (EntryCertificateResource
 		return EntryCertificateResourceModelData{}, diags
 	}
 
+	diags.Append(model.Pem.As(ctx, &pemPlan, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return EntryCertificateResourceModelData{}, diags
+	}
+
 	return EntryCertificateResourceModelData{
 		Data: model,
 		File: filePlan,
 		Url:  urlPlan,
+		Pem:  pemPlan,
 	}, diags
 }
 
-func updateCertificateContent(plans EntryCertificateResourceModelData, client *dvls.Client, entrycertificate dvls.EntryCertificate, diags *diag.Diagnostics) dvls.EntryCertificate {
+func updateCertificateContent(ctx context.Context, plans EntryCertificateResourceModelData, client *dvls.Client, entrycertificate dvls.EntryCertificate, diags *diag.Diagnostics) dvls.EntryCertificate {
 	var err error
 
 	if !plans.Data.File.IsNull() {
@@ -235,13 +261,31 @@ func updateCertificateContent(plans EntryCertificateResourceModelData, client *d
 			return dvls.EntryCertificate{}
 		}
 
+		logResult := logDvlsCall(ctx, "NewFile", "certificate", entrycertificate.VaultId, entrycertificate.ID)
+		entrycertificate, err = client.Entries.Certificate.NewFile(entrycertificate, content)
+		logResult(err)
+		if err != nil {
+			diags.AddError("unable to update certificate entry", err.Error())
+			return dvls.EntryCertificate{}
+		}
+	} else if !plans.Data.Pem.IsNull() {
+		content, err := newPKCS12FromPEM(plans.Pem.Certificate.ValueString(), plans.Pem.Chain.ValueString(), plans.Pem.PrivateKey.ValueString(), plans.Data.Password.ValueString())
+		if err != nil {
+			diags.AddError("unable to update certificate entry", err.Error())
+			return dvls.EntryCertificate{}
+		}
+
+		logResult := logDvlsCall(ctx, "NewFile", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 		entrycertificate, err = client.Entries.Certificate.NewFile(entrycertificate, content)
+		logResult(err)
 		if err != nil {
 			diags.AddError("unable to update certificate entry", err.Error())
 			return dvls.EntryCertificate{}
 		}
 	} else {
+		logResult := logDvlsCall(ctx, "NewURL", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 		entrycertificate, err = client.Entries.Certificate.NewURL(entrycertificate)
+		logResult(err)
 		if err != nil {
 			diags.AddError("unable to update certificate entry", err.Error())
 			return dvls.EntryCertificate{}
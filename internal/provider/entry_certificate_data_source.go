@@ -175,6 +175,8 @@ func (d *EntryCertificateDataSource) Configure(ctx context.Context, req datasour
 }
 
 func (d *EntryCertificateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var data *EntryCertificateDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -184,7 +186,9 @@ func (d *EntryCertificateDataSource) Read(ctx context.Context, req datasource.Re
 
 	entrycertificateId := data.Id.ValueString()
 
+	logResult := logDvlsCall(ctx, "Get", "certificate", "", entrycertificateId)
 	entrycertificate, err := d.client.Entries.Certificate.Get(entrycertificateId)
+	logResult(err)
 	if err != nil {
 		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
 			resp.State.RemoveResource(ctx)
@@ -194,13 +198,17 @@ func (d *EntryCertificateDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetPassword", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	entrycertificate, err = d.client.Entries.Certificate.GetPassword(entrycertificate)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read certificate entry sensitive information", err.Error())
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetFileContent", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	entryBytes, err := d.client.Entries.Certificate.GetFileContent(entrycertificate.ID)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read certificate entry content", err.Error())
 		return
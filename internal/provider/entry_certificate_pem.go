@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// newPKCS12FromPEM bundles a PEM encoded certificate, its optional chain and its private key
+// into a PKCS#12 archive protected by password.
+func newPKCS12FromPEM(certificatePEM string, chainPEM string, privateKeyPEM string, password string) ([]byte, error) {
+	certificates, err := parsePEMCertificates([]byte(certificatePEM))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse certificate. error: %w", err)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificate found in certificate PEM")
+	}
+
+	// Anything after the leaf certificate is considered part of the chain.
+	certificate := certificates[0]
+	caCerts := certificates[1:]
+
+	if chainPEM != "" {
+		chain, err := parsePEMCertificates([]byte(chainPEM))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate chain. error: %w", err)
+		}
+
+		caCerts = append(caCerts, chain...)
+	}
+
+	privateKey, err := parsePEMPrivateKey([]byte(privateKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key. error: %w", err)
+	}
+
+	if !privateKeyMatchesCertificate(privateKey, certificate) {
+		return nil, errors.New("private key does not match the certificate public key")
+	}
+
+	content, err := pkcs12.Modern.Encode(privateKey, certificate, caCerts, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode PKCS#12. error: %w", err)
+	}
+
+	return content, nil
+}
+
+func parsePEMCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block type %s", block.Type)
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+// parsePEMBundleCertificates returns the certificates of a PEM bundle, skipping other blocks such as
+// the private key of files holding both the certificate and its key.
+func parsePEMBundleCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificate found in PEM content")
+	}
+
+	return certificates, nil
+}
+
+func parsePEMPrivateKey(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %s", block.Type)
+	}
+}
+
+func privateKeyMatchesCertificate(privateKey crypto.PrivateKey, certificate *x509.Certificate) bool {
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return key.PublicKey.Equal(certificate.PublicKey)
+	case *ecdsa.PrivateKey:
+		return key.PublicKey.Equal(certificate.PublicKey)
+	case ed25519.PrivateKey:
+		publicKey, ok := certificate.PublicKey.(ed25519.PublicKey)
+		return ok && publicKey.Equal(key.Public())
+	default:
+		return false
+	}
+}
+
+// parseCertificateContent returns the leaf certificate of content, which can either be a PKCS#12
+// archive protected by password or a PEM or DER encoded certificate. pkcs12.ErrIncorrectPassword
+// is returned when content is a PKCS#12 archive that cannot be opened with password.
+func parseCertificateContent(content []byte, password string) (*x509.Certificate, error) {
+	_, certificate, _, err := pkcs12.DecodeChain(content, password)
+	if err == nil {
+		return certificate, nil
+	} else if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		return nil, err
+	}
+
+	// PKCS#12 archives without a private key only hold certificates.
+	certificates, err := pkcs12.DecodeTrustStore(content, password)
+	if err == nil && len(certificates) > 0 {
+		return certificates[0], nil
+	} else if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		return nil, err
+	}
+
+	certificates, err = parsePEMBundleCertificates(content)
+	if err == nil {
+		return certificates[0], nil
+	}
+
+	certificate, err = x509.ParseCertificate(content)
+	if err != nil {
+		return nil, errors.New("content is neither a PKCS#12 archive nor a PEM or DER encoded certificate")
+	}
+
+	return certificate, nil
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestNewPKCS12FromPEM(t *testing.T) {
+	certificatePEM, privateKeyPEM := testCertificatePEM(t, time.Now().Add(24*time.Hour))
+	_, otherPrivateKeyPEM := testCertificatePEM(t, time.Now().Add(24*time.Hour))
+
+	content, err := newPKCS12FromPEM(certificatePEM, "", privateKeyPEM, "password")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, certificate, _, err := pkcs12.DecodeChain(content, "password")
+	if err != nil {
+		t.Fatalf("unable to decode PKCS#12: %s", err)
+	}
+
+	if certificate.Subject.CommonName != "dvls.test" {
+		t.Errorf("unexpected certificate subject %s", certificate.Subject.CommonName)
+	}
+
+	_, err = newPKCS12FromPEM(certificatePEM, "", otherPrivateKeyPEM, "password")
+	if err == nil {
+		t.Error("expected an error for a private key not matching the certificate")
+	}
+
+	_, err = newPKCS12FromPEM("", "", privateKeyPEM, "password")
+	if err == nil {
+		t.Error("expected an error for an empty certificate")
+	}
+}
+
+func TestParseCertificateContent(t *testing.T) {
+	certificatePEM, privateKeyPEM := testCertificatePEM(t, time.Now().Add(24*time.Hour))
+
+	content, err := newPKCS12FromPEM(certificatePEM, "", privateKeyPEM, "password")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = parseCertificateContent(content, "password")
+	if err != nil {
+		t.Errorf("unexpected error for PKCS#12 content: %s", err)
+	}
+
+	_, err = parseCertificateContent(content, "wrong")
+	if !errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		t.Errorf("expected incorrect password error, got %v", err)
+	}
+
+	certificate, err := parseCertificateContent([]byte(certificatePEM), "")
+	if err != nil {
+		t.Fatalf("unexpected error for PEM content: %s", err)
+	}
+
+	_, err = parseCertificateContent([]byte(certificatePEM+privateKeyPEM), "")
+	if err != nil {
+		t.Errorf("unexpected error for PEM content holding the private key: %s", err)
+	}
+
+	_, err = parseCertificateContent([]byte(privateKeyPEM), "")
+	if err == nil {
+		t.Error("expected an error for PEM content without certificate")
+	}
+
+	_, err = parseCertificateContent(certificate.Raw, "")
+	if err != nil {
+		t.Errorf("unexpected error for DER content: %s", err)
+	}
+
+	_, err = parseCertificateContent([]byte("not a certificate"), "")
+	if err == nil {
+		t.Error("expected an error for invalid content")
+	}
+}
+
+func testCertificatePEM(t *testing.T, notAfter time.Time) (string, string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dvls.test"},
+		DNSNames:     []string{"dvls.test"},
+		NotBefore:    notAfter.Add(-48 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes})
+
+	return string(certificatePEM), string(privateKeyPEM)
+}
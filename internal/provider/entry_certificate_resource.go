@@ -2,8 +2,12 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Devolutions/go-dvls"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
@@ -18,11 +22,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"software.sslmate.com/src/go-pkcs12"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &EntryCertificateResource{}
 var _ resource.ResourceWithImportState = &EntryCertificateResource{}
+var _ resource.ResourceWithValidateConfig = &EntryCertificateResource{}
+var _ resource.ResourceWithModifyPlan = &EntryCertificateResource{}
+var _ resource.ResourceWithIdentity = &EntryCertificateResource{}
 
 func NewEntryCertificateResource() resource.Resource {
 	return &EntryCertificateResource{}
@@ -30,27 +38,33 @@ func NewEntryCertificateResource() resource.Resource {
 
 // EntryCertificateResource defines the resource implementation.
 type EntryCertificateResource struct {
-	client *dvls.Client
+	client        *dvls.Client
+	protectedTags []string
 }
 
 // EntryCertificateResourceModel describes the resource data model.
 type EntryCertificateResourceModel struct {
-	Id          types.String      `tfsdk:"id"`
-	VaultId     types.String      `tfsdk:"vault_id"`
-	Name        types.String      `tfsdk:"name"`
-	Description types.String      `tfsdk:"description"`
-	Password    types.String      `tfsdk:"password"`
-	Folder      types.String      `tfsdk:"folder"`
-	Url         types.Object      `tfsdk:"url"`
-	File        types.Object      `tfsdk:"file"`
-	Expiration  timetypes.RFC3339 `tfsdk:"expiration"`
-	Tags        []types.String    `tfsdk:"tags"`
+	Id           types.String      `tfsdk:"id"`
+	VaultId      types.String      `tfsdk:"vault_id"`
+	Name         types.String      `tfsdk:"name"`
+	Description  types.String      `tfsdk:"description"`
+	Password     types.String      `tfsdk:"password"`
+	Folder       types.String      `tfsdk:"folder"`
+	Url          types.Object      `tfsdk:"url"`
+	File         types.Object      `tfsdk:"file"`
+	Pem          types.Object      `tfsdk:"pem"`
+	Expiration   timetypes.RFC3339 `tfsdk:"expiration"`
+	AllowExpired types.Bool        `tfsdk:"allow_expired"`
+	Tags         []types.String    `tfsdk:"tags"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
 }
 
 type EntryCertificateResourceModelData struct {
 	Data *EntryCertificateResourceModel
 	Url  *EntryCertificateResourceModelUrl
 	File *EntryCertificateResourceModelFile
+	Pem  *EntryCertificateResourceModelPem
 }
 
 type EntryCertificateResourceModelUrl struct {
@@ -77,6 +91,22 @@ func (m EntryCertificateResourceModelFile) AttributeTypes() map[string]attr.Type
 	}
 }
 
+type EntryCertificateResourceModelPem struct {
+	Certificate types.String `tfsdk:"certificate"`
+	Chain       types.String `tfsdk:"chain"`
+	PrivateKey  types.String `tfsdk:"private_key"`
+	Name        types.String `tfsdk:"name"`
+}
+
+func (m EntryCertificateResourceModelPem) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"certificate": types.StringType,
+		"chain":       types.StringType,
+		"private_key": types.StringType,
+		"name":        types.StringType,
+	}
+}
+
 func (r *EntryCertificateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_entry_certificate"
 }
@@ -105,9 +135,16 @@ func (r *EntryCertificateResource) Schema(ctx context.Context, req resource.Sche
 				Optional:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "Certificate password",
+				Description: "Certificate password. Changing it replaces the entry when pem is set, as the uploaded PKCS#12 file is protected by the password.",
 				Optional:    true,
 				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfPem,
+						"Changing the password replaces the entry when pem is set.",
+						"Changing the password replaces the entry when `pem` is set.",
+					),
+				},
 			},
 			"folder": schema.StringAttribute{
 				Description: "Certificate folder path",
@@ -115,7 +152,7 @@ func (r *EntryCertificateResource) Schema(ctx context.Context, req resource.Sche
 			},
 
 			"url": schema.SingleNestedAttribute{
-				Description:   "Certificate url. Either file or url must be specified.",
+				Description:   "Certificate url. Exactly one of file, pem or url must be specified.",
 				Optional:      true,
 				PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplaceIfConfigured()},
 
@@ -131,11 +168,11 @@ func (r *EntryCertificateResource) Schema(ctx context.Context, req resource.Sche
 						Default:     booldefault.StaticBool(false),
 					},
 				},
-				Validators: []validator.Object{objectvalidator.ExactlyOneOf(path.MatchRoot("file"))},
+				Validators: []validator.Object{objectvalidator.ExactlyOneOf(path.MatchRoot("file"), path.MatchRoot("pem"))},
 			},
 
 			"file": schema.SingleNestedAttribute{
-				Description:   "Certificate file. Either file or url must be specified.",
+				Description:   "Certificate file. Exactly one of file, pem or url must be specified.",
 				Optional:      true,
 				PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplaceIfConfigured()},
 				Sensitive:     true,
@@ -151,7 +188,38 @@ func (r *EntryCertificateResource) Schema(ctx context.Context, req resource.Sche
 						Required:    true,
 					},
 				},
-				Validators: []validator.Object{objectvalidator.ExactlyOneOf(path.MatchRoot("url"))},
+				Validators: []validator.Object{objectvalidator.ExactlyOneOf(path.MatchRoot("url"), path.MatchRoot("pem"))},
+			},
+
+			"pem": schema.SingleNestedAttribute{
+				Description:   "Certificate in PEM format, bundled into a PKCS#12 file protected by the certificate password before being uploaded. Exactly one of file, pem or url must be specified.",
+				Optional:      true,
+				PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplaceIfConfigured()},
+				Sensitive:     true,
+
+				Attributes: map[string]schema.Attribute{
+					"certificate": schema.StringAttribute{
+						Description: "PEM encoded certificate. Additional certificates following the first one are added to the chain.",
+						Required:    true,
+					},
+					"chain": schema.StringAttribute{
+						Description: "PEM encoded intermediate certificates",
+						Optional:    true,
+					},
+					"private_key": schema.StringAttribute{
+						Description: "PEM encoded private key (PKCS#1, PKCS#8 or SEC 1)",
+						Required:    true,
+						Sensitive:   true,
+					},
+					"name": schema.StringAttribute{
+						Description: "Certificate file name",
+						Required:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRoot("file"), path.MatchRoot("url")),
+					objectvalidator.AlsoRequires(path.MatchRoot("password")),
+				},
 			},
 
 			"expiration": schema.StringAttribute{
@@ -159,56 +227,211 @@ func (r *EntryCertificateResource) Schema(ctx context.Context, req resource.Sche
 				Description: "Certificate expiration date, in RFC3339 format (e.g. 2022-12-31T23:59:59-05:00)",
 				Required:    true,
 			},
+			"allow_expired": schema.BoolAttribute{
+				Description: "Allow the certificate content to be expired. Expired certificates are rejected at plan time otherwise.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"tags": schema.ListAttribute{
 				ElementType: types.StringType,
 				Description: "Certificate tags",
 				Optional:    true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Prevent the certificate from being deleted. Must be set to false and applied before the entry can be destroyed.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
+// requiresReplaceIfPem requires the entry to be replaced when its content comes from pem. The PKCS#12
+// file built from pem is encrypted with the password, and Update does not upload it again.
+func requiresReplaceIfPem(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var pem types.Object
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pem"), &pem)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.RequiresReplace = !pem.IsNull()
+}
+
+func (r *EntryCertificateResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
 func (r *EntryCertificateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*dvls.Client)
+	data, ok := req.ProviderData.(*DvlsResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dvls.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DvlsResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.protectedTags = data.ProtectedTags
+}
+
+func (r *EntryCertificateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var contentB64 types.String
+	var password types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("file").AtName("content_b64"), &contentB64)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if contentB64.IsNull() || contentB64.IsUnknown() {
+		return
+	}
+
+	content, err := base64.StdEncoding.DecodeString(contentB64.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("file").AtName("content_b64"), "invalid certificate content", fmt.Sprintf("content_b64 is not a valid base 64 encoded string. error: %s", err.Error()))
+		return
+	}
+
+	if password.IsUnknown() {
+		return
+	}
+
+	_, err = parseCertificateContent(content, password.ValueString())
+	if errors.Is(err, pkcs12.ErrIncorrectPassword) && password.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("password"), "missing certificate password", "the certificate content is protected by a password, set password to open it")
+	} else if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		resp.Diagnostics.AddAttributeError(path.Root("password"), "invalid certificate password", "the certificate content cannot be opened with the configured password")
+	} else if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("file").AtName("content_b64"), "invalid certificate content", err.Error())
+	}
+}
+
+func (r *EntryCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var allowExpired types.Bool
+	var password types.String
+	var contentB64 types.String
+	var certificatePEM types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_expired"), &allowExpired)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("password"), &password)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("file").AtName("content_b64"), &contentB64)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pem").AtName("certificate"), &certificatePEM)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if allowExpired.ValueBool() {
+		return
+	}
+
+	var certificate *x509.Certificate
+	var certificatePath path.Path
+	var planContent types.String
+
+	if !contentB64.IsNull() && !contentB64.IsUnknown() {
+		if password.IsUnknown() {
+			return
+		}
+
+		content, err := base64.StdEncoding.DecodeString(contentB64.ValueString())
+		if err != nil {
+			return
+		}
+
+		// Invalid content is reported by ValidateConfig.
+		certificate, err = parseCertificateContent(content, password.ValueString())
+		if err != nil {
+			return
+		}
+
+		certificatePath = path.Root("file").AtName("content_b64")
+		planContent = contentB64
+	} else if !certificatePEM.IsNull() && !certificatePEM.IsUnknown() {
+		certificates, err := parsePEMCertificates([]byte(certificatePEM.ValueString()))
+		if err != nil || len(certificates) == 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("pem").AtName("certificate"), "invalid certificate content", "pem.certificate does not contain a valid PEM encoded certificate")
+			return
+		}
+
+		certificate = certificates[0]
+		certificatePath = path.Root("pem").AtName("certificate")
+		planContent = certificatePEM
+	} else {
+		return
+	}
+
+	if !time.Now().After(certificate.NotAfter) {
+		return
+	}
+
+	detail := fmt.Sprintf("the certificate %s expired on %s. Set allow_expired to true to allow expired certificates.", certificate.Subject.String(), certificate.NotAfter.Format(time.RFC3339))
+
+	// Only new content is rejected, so that certificates expiring in place do not block every plan.
+	if !req.State.Raw.IsNull() {
+		var stateContent types.String
+
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, certificatePath, &stateContent)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if stateContent.Equal(planContent) {
+			resp.Diagnostics.AddAttributeWarning(certificatePath, "certificate is expired", detail)
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(certificatePath, "certificate is expired", detail)
 }
 
 func (r *EntryCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	plans, diags := getPlans(ctx, req.Plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx = maskDvlsLogSecrets(ctx, plans.Data.Password.ValueString())
+
 	entrycertificate := newEntryCertificateFromResourceModel(&plans)
 
-	entrycertificate = updateCertificateContent(plans, r.client, entrycertificate, &resp.Diagnostics)
+	entrycertificate = updateCertificateContent(ctx, plans, r.client, entrycertificate, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	logResult := logDvlsCall(ctx, "GetPassword", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	entrycertificate, err := r.client.Entries.Certificate.GetPassword(entrycertificate)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read certificate entry sensitive information", err.Error())
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetFileContent", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	entryBytes, err := r.client.Entries.Certificate.GetFileContent(entrycertificate.ID)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read certificate entry content", err.Error())
 		return
@@ -221,18 +444,25 @@ func (r *EntryCertificateResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plans.Data)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, plans.Data.VaultId, plans.Data.Id)...)
 }
 
 func (r *EntryCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	states, diags := getPlans(ctx, req.State)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx = maskDvlsLogSecrets(ctx, states.Data.Password.ValueString())
+
 	entrycertificate := newEntryCertificateFromResourceModel(&states)
 
+	logResult := logDvlsCall(ctx, "Get", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	entrycertificate, err := r.client.Entries.Certificate.Get(entrycertificate.ID)
+	logResult(err)
 	if err != nil {
 		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
 			resp.State.RemoveResource(ctx)
@@ -242,13 +472,17 @@ func (r *EntryCertificateResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetPassword", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	entrycertificate, err = r.client.Entries.Certificate.GetPassword(entrycertificate)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read certificate entry sensitive information", err.Error())
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetFileContent", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	entryBytes, err := r.client.Entries.Certificate.GetFileContent(entrycertificate.ID)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read certificate entry content", err.Error())
 		return
@@ -261,27 +495,37 @@ func (r *EntryCertificateResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &states.Data)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, states.Data.VaultId, states.Data.Id)...)
 }
 
 func (r *EntryCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	plans, diags := getPlans(ctx, req.Plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx = maskDvlsLogSecrets(ctx, plans.Data.Password.ValueString())
+
 	entrycertificate := newEntryCertificateFromResourceModel(&plans)
 
+	logResult := logDvlsCall(ctx, "Update", "certificate", entrycertificate.VaultId, entrycertificate.ID)
 	_, err := r.client.Entries.Certificate.Update(entrycertificate)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to update certificate entry", err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plans.Data)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, plans.Data.VaultId, plans.Data.Id)...)
 }
 
 func (r *EntryCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var state *EntryCertificateResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -289,7 +533,26 @@ func (r *EntryCertificateResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	err := r.client.Entries.Certificate.Delete(state.Id.ValueString())
+	logResult := logDvlsCall(ctx, "Get", "certificate", state.VaultId.ValueString(), state.Id.ValueString())
+	entrycertificate, err := r.client.Entries.Certificate.Get(state.Id.ValueString())
+	logResult(err)
+	if err != nil {
+		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("unable to read certificate entry", err.Error())
+		return
+	}
+
+	checkEntryDeletionProtection(entrycertificate.Name, entrycertificate.ID, state.DeletionProtection, entrycertificate.Tags, r.protectedTags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logResult = logDvlsCall(ctx, "Delete", "certificate", state.VaultId.ValueString(), state.Id.ValueString())
+	err = r.client.Entries.Certificate.Delete(state.Id.ValueString())
+	logResult(err)
 	if err != nil {
 		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
 			resp.State.RemoveResource(ctx)
@@ -301,5 +564,34 @@ func (r *EntryCertificateResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *EntryCertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx = newDvlsLogContext(ctx)
+
+	importId, diags := getEntryImportId(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaultId, entryId, err := parseEntryImportId(importId)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to import certificate entry", err.Error())
+		return
+	}
+
+	logResult := logDvlsCall(ctx, "Get", "certificate", vaultId, entryId)
+	entrycertificate, err := r.client.Entries.Certificate.Get(entryId)
+	logResult(err)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to import certificate entry", err.Error())
+		return
+	}
+
+	if vaultId != "" && !strings.EqualFold(entrycertificate.VaultId, vaultId) {
+		resp.Diagnostics.AddError("unable to import certificate entry", fmt.Sprintf("entry %s belongs to vault %s, not %s", entryId, entrycertificate.VaultId, vaultId))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), entrycertificate.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vault_id"), entrycertificate.VaultId)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, types.StringValue(entrycertificate.VaultId), types.StringValue(entrycertificate.ID))...)
 }
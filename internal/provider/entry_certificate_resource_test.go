@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEntryCertificatePasswordRequiresReplaceIfPem(t *testing.T) {
+	ctx := context.Background()
+
+	pemModel := testEntryCertificateModel()
+	pemModel.Pem = testEntryCertificatePem(t, "certificate", "private key")
+
+	tests := map[string]struct {
+		model    *EntryCertificateResourceModel
+		expected bool
+	}{
+		"pem":  {model: pemModel, expected: true},
+		"file": {model: testEntryCertificateModel(), expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.model.Password = types.StringValue("new")
+
+			req := planmodifier.StringRequest{
+				Path:       path.Root("password"),
+				Plan:       tfsdk.Plan(testEntryCertificateState(t, test.model)),
+				PlanValue:  types.StringValue("new"),
+				StateValue: types.StringValue("old"),
+			}
+
+			var resp stringplanmodifier.RequiresReplaceIfFuncResponse
+			requiresReplaceIfPem(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if resp.RequiresReplace != test.expected {
+				t.Errorf("RequiresReplace = %t, expected %t", resp.RequiresReplace, test.expected)
+			}
+		})
+	}
+}
+
+func TestEntryCertificateResourceValidateConfig(t *testing.T) {
+	certificatePEM, privateKeyPEM := testCertificatePEM(t, time.Now().Add(24*time.Hour))
+
+	content, err := newPKCS12FromPEM(certificatePEM, "", privateKeyPEM, "password")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := map[string]struct {
+		contentB64   string
+		password     types.String
+		errorPath    *path.Path
+		errorSummary string
+	}{
+		"pkcs12":              {contentB64: base64.StdEncoding.EncodeToString(content), password: types.StringValue("password")},
+		"pem with key":        {contentB64: base64.StdEncoding.EncodeToString([]byte(certificatePEM + privateKeyPEM)), password: types.StringNull()},
+		"wrong password":      {contentB64: base64.StdEncoding.EncodeToString(content), password: types.StringValue("wrong"), errorPath: pointer(path.Root("password")), errorSummary: "invalid certificate password"},
+		"missing password":    {contentB64: base64.StdEncoding.EncodeToString(content), password: types.StringNull(), errorPath: pointer(path.Root("password")), errorSummary: "missing certificate password"},
+		"invalid base64":      {contentB64: "not base64", password: types.StringNull(), errorPath: pointer(path.Root("file").AtName("content_b64"))},
+		"invalid certificate": {contentB64: base64.StdEncoding.EncodeToString([]byte("not a certificate")), password: types.StringNull(), errorPath: pointer(path.Root("file").AtName("content_b64"))},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			model := testEntryCertificateModel()
+			model.Password = test.password
+			model.File = testEntryCertificateFile(t, test.contentB64)
+
+			req := resource.ValidateConfigRequest{Config: tfsdk.Config(testEntryCertificateState(t, model))}
+			var resp resource.ValidateConfigResponse
+
+			NewEntryCertificateResource().(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), req, &resp)
+
+			testExpectDiagnostic(t, resp.Diagnostics, diag.SeverityError, test.errorPath)
+
+			if test.errorSummary != "" && resp.Diagnostics[0].Summary() != test.errorSummary {
+				t.Errorf("unexpected error summary %q, expected %q", resp.Diagnostics[0].Summary(), test.errorSummary)
+			}
+		})
+	}
+}
+
+func TestEntryCertificateResourceModifyPlan(t *testing.T) {
+	validPEM, validKeyPEM := testCertificatePEM(t, time.Now().Add(24*time.Hour))
+	expiredPEM, expiredKeyPEM := testCertificatePEM(t, time.Now().Add(-time.Hour))
+	expiredB64 := base64.StdEncoding.EncodeToString([]byte(expiredPEM))
+
+	filePath := path.Root("file").AtName("content_b64")
+	pemPath := path.Root("pem").AtName("certificate")
+
+	tests := map[string]struct {
+		plan     func(*EntryCertificateResourceModel)
+		state    func(*EntryCertificateResourceModel)
+		severity diag.Severity
+		path     *path.Path
+	}{
+		"valid file": {
+			plan: func(m *EntryCertificateResourceModel) {
+				m.File = testEntryCertificateFile(t, base64.StdEncoding.EncodeToString([]byte(validPEM)))
+			},
+		},
+		"expired file on create": {
+			plan:     func(m *EntryCertificateResourceModel) { m.File = testEntryCertificateFile(t, expiredB64) },
+			severity: diag.SeverityError,
+			path:     &filePath,
+		},
+		"expired pem on create with unknown password": {
+			plan: func(m *EntryCertificateResourceModel) {
+				m.Pem = testEntryCertificatePem(t, expiredPEM, expiredKeyPEM)
+				m.Password = types.StringUnknown()
+			},
+			severity: diag.SeverityError,
+			path:     &pemPath,
+		},
+		"expired pem replacing valid pem": {
+			plan:     func(m *EntryCertificateResourceModel) { m.Pem = testEntryCertificatePem(t, expiredPEM, expiredKeyPEM) },
+			state:    func(m *EntryCertificateResourceModel) { m.Pem = testEntryCertificatePem(t, validPEM, validKeyPEM) },
+			severity: diag.SeverityError,
+			path:     &pemPath,
+		},
+		"unchanged expired file": {
+			plan:     func(m *EntryCertificateResourceModel) { m.File = testEntryCertificateFile(t, expiredB64) },
+			state:    func(m *EntryCertificateResourceModel) { m.File = testEntryCertificateFile(t, expiredB64) },
+			severity: diag.SeverityWarning,
+			path:     &filePath,
+		},
+		"allowed expired file": {
+			plan: func(m *EntryCertificateResourceModel) {
+				m.File = testEntryCertificateFile(t, expiredB64)
+				m.AllowExpired = types.BoolValue(true)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			planModel := testEntryCertificateModel()
+			test.plan(planModel)
+			plan := tfsdk.Plan(testEntryCertificateState(t, planModel))
+
+			state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+			if test.state != nil {
+				stateModel := testEntryCertificateModel()
+				test.state(stateModel)
+				state = testEntryCertificateState(t, stateModel)
+			}
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config(plan), Plan: plan, State: state}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+
+			NewEntryCertificateResource().(resource.ResourceWithModifyPlan).ModifyPlan(context.Background(), req, &resp)
+
+			if test.path == nil && len(resp.Diagnostics) > 0 {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if test.path != nil {
+				testExpectDiagnostic(t, resp.Diagnostics, test.severity, test.path)
+			}
+		})
+	}
+}
+
+// testExpectDiagnostic fails unless diags holds exactly one diagnostic, of severity and on path, or
+// no error at all when path is nil.
+func testExpectDiagnostic(t *testing.T, diags diag.Diagnostics, severity diag.Severity, attributePath *path.Path) {
+	t.Helper()
+
+	if attributePath == nil {
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		return
+	}
+
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic on %s, got: %v", attributePath, diags)
+	}
+
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || diags[0].Severity() != severity || !withPath.Path().Equal(*attributePath) {
+		t.Errorf("expected a diagnostic of severity %s on %s, got: %v", severity, attributePath, diags[0])
+	}
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+// testEntryCertificateModel returns a certificate model without content and with every optional attribute null.
+func testEntryCertificateModel() *EntryCertificateResourceModel {
+	return &EntryCertificateResourceModel{
+		Id:           types.StringNull(),
+		VaultId:      types.StringValue("00000000-0000-0000-0000-000000000000"),
+		Name:         types.StringValue("certificate"),
+		Description:  types.StringNull(),
+		Password:     types.StringNull(),
+		Folder:       types.StringNull(),
+		Url:          types.ObjectNull(EntryCertificateResourceModelUrl{}.AttributeTypes()),
+		File:         types.ObjectNull(EntryCertificateResourceModelFile{}.AttributeTypes()),
+		Pem:          types.ObjectNull(EntryCertificateResourceModelPem{}.AttributeTypes()),
+		Expiration:   timetypes.NewRFC3339Null(),
+		AllowExpired: types.BoolValue(false),
+
+		DeletionProtection: types.BoolValue(false),
+	}
+}
+
+func testEntryCertificateFile(t *testing.T, contentB64 string) types.Object {
+	t.Helper()
+
+	file, diags := types.ObjectValueFrom(context.Background(), EntryCertificateResourceModelFile{}.AttributeTypes(), EntryCertificateResourceModelFile{
+		ContentB64: types.StringValue(contentB64),
+		Name:       types.StringValue("certificate.pfx"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to build file: %v", diags)
+	}
+
+	return file
+}
+
+func testEntryCertificatePem(t *testing.T, certificatePEM string, privateKeyPEM string) types.Object {
+	t.Helper()
+
+	pem, diags := types.ObjectValueFrom(context.Background(), EntryCertificateResourceModelPem{}.AttributeTypes(), EntryCertificateResourceModelPem{
+		Certificate: types.StringValue(certificatePEM),
+		Chain:       types.StringNull(),
+		PrivateKey:  types.StringValue(privateKeyPEM),
+		Name:        types.StringValue("certificate.pfx"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to build pem: %v", diags)
+	}
+
+	return pem
+}
+
+// testEntryCertificateState returns model as a state of the certificate resource schema. It converts to
+// tfsdk.Plan and tfsdk.Config, which share the same fields.
+func testEntryCertificateState(t *testing.T, model *EntryCertificateResourceModel) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewEntryCertificateResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	return state
+}
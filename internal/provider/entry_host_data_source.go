@@ -109,6 +109,8 @@ func (d *EntryHostDataSource) Configure(ctx context.Context, req datasource.Conf
 }
 
 func (d *EntryHostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var data EntryHostDataSourceModel
 
 	diags := req.Config.Get(ctx, &data)
@@ -117,7 +119,9 @@ func (d *EntryHostDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	logResult := logDvlsCall(ctx, "Get", "host", "", data.Id.ValueString())
 	entryHost, err := d.client.Entries.Host.Get(data.Id.ValueString())
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Host Entry",
@@ -126,7 +130,9 @@ func (d *EntryHostDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetHostDetails", "host", entryHost.VaultId, entryHost.ID)
 	entryHostSensitiveData, err := d.client.Entries.Host.GetHostDetails(entryHost)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Host Entry Sensitive Data",
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// EntryResourceIdentityModel describes the identity shared by entry resources. It only holds the
+// vault and entry IDs, which are never changed by the entry model state upgrades.
+type EntryResourceIdentityModel struct {
+	VaultId types.String `tfsdk:"vault_id"`
+	Id      types.String `tfsdk:"id"`
+}
+
+func entryIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"vault_id": identityschema.StringAttribute{
+				Description:       "Vault ID",
+				RequiredForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "Entry ID",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func setEntryIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, vaultId types.String, id types.String) diag.Diagnostics {
+	return identity.Set(ctx, EntryResourceIdentityModel{
+		VaultId: vaultId,
+		Id:      id,
+	})
+}
+
+// getEntryImportId returns the entry import ID, built from the identity when the entry is
+// imported through an import block identity instead of an import ID.
+func getEntryImportId(ctx context.Context, req resource.ImportStateRequest) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if req.ID != "" || req.Identity == nil {
+		return req.ID, diags
+	}
+
+	var identity EntryResourceIdentityModel
+
+	diags.Append(req.Identity.Get(ctx, &identity)...)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	return identity.VaultId.ValueString() + "/" + identity.Id.ValueString(), diags
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseEntryImportId parses an entry import ID, either "<entry_id>" or "<vault_id>/<entry_id>". IDs
+// must be UUIDs in their canonical form.
+// The vault ID is empty when the import ID only holds the entry ID.
+func parseEntryImportId(importId string) (string, string, error) {
+	parts := strings.Split(importId, "/")
+
+	switch len(parts) {
+	case 1:
+		if !isUUID(parts[0]) {
+			return "", "", fmt.Errorf("entry id %q is not a valid UUID (ex.: 00000000-0000-0000-0000-000000000000)", parts[0])
+		}
+
+		return "", parts[0], nil
+	case 2:
+		if !isUUID(parts[0]) {
+			return "", "", fmt.Errorf("vault id %q is not a valid UUID. Importing entries by vault name or folder path is not supported, use <vault_id>/<entry_id>", parts[0])
+		}
+
+		if !isUUID(parts[1]) {
+			return "", "", fmt.Errorf("entry id %q is not a valid UUID. Importing entries by name is not supported, use <vault_id>/<entry_id>", parts[1])
+		}
+
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("import id %q must be either <entry_id> or <vault_id>/<entry_id>. Importing entries by folder path is not supported", importId)
+	}
+}
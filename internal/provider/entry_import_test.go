@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Devolutions/go-dvls"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseEntryImportId(t *testing.T) {
+	const vaultId = "5b1e6f3c-0a8a-4d4e-9c1f-0e6b7d2a9f10"
+	const entryId = "b4c2a1d0-7e3f-4a5b-8c9d-1e2f3a4b5c6d"
+
+	tests := []struct {
+		importId    string
+		wantVaultId string
+		wantEntryId string
+		wantErr     bool
+	}{
+		{importId: entryId, wantEntryId: entryId},
+		{importId: vaultId + "/" + entryId, wantVaultId: vaultId, wantEntryId: entryId},
+		{importId: "not-a-uuid", wantErr: true},
+		{importId: "{" + entryId + "}", wantErr: true},
+		{importId: "urn:uuid:" + entryId, wantErr: true},
+		{importId: strings.ReplaceAll(entryId, "-", ""), wantErr: true},
+		{importId: "{" + vaultId + "}/" + entryId, wantErr: true},
+		{importId: "my vault/" + entryId, wantErr: true},
+		{importId: vaultId + "/my entry", wantErr: true},
+		{importId: vaultId + "/folder/my entry", wantErr: true},
+	}
+
+	for _, test := range tests {
+		vaultId, entryId, err := parseEntryImportId(test.importId)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseEntryImportId(%q) error = %v, wantErr %v", test.importId, err, test.wantErr)
+			continue
+		}
+
+		if vaultId != test.wantVaultId || entryId != test.wantEntryId {
+			t.Errorf("parseEntryImportId(%q) = %q, %q, want %q, %q", test.importId, vaultId, entryId, test.wantVaultId, test.wantEntryId)
+		}
+	}
+}
+
+func TestEntryUserCredentialResourceImportState(t *testing.T) {
+	const vaultId = "5b1e6f3c-0a8a-4d4e-9c1f-0e6b7d2a9f10"
+	const entryId = "b4c2a1d0-7e3f-4a5b-8c9d-1e2f3a4b5c6d"
+	const otherVaultId = "0f9e8d7c-6b5a-4f3e-8d2c-1b0a9f8e7d6c"
+
+	client := testDvlsClient(t, map[string]string{
+		"/api/connections/partial/" + entryId: fmt.Sprintf(`{"result": 1, "data": {"id": %q, "name": "entry", "repositoryId": %q}}`, entryId, vaultId),
+	})
+
+	tests := map[string]struct {
+		importId string
+		wantErr  bool
+	}{
+		"entry id":                  {importId: entryId},
+		"vault and entry ids":       {importId: vaultId + "/" + entryId},
+		"uppercase vault id":        {importId: strings.ToUpper(vaultId) + "/" + entryId},
+		"vault mismatch":            {importId: otherVaultId + "/" + entryId, wantErr: true},
+		"non canonical entry id":    {importId: "{" + entryId + "}", wantErr: true},
+		"unknown entry":             {importId: vaultId + "/" + otherVaultId, wantErr: true},
+		"vault name and entry name": {importId: "vault/entry", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &EntryUserCredentialResource{client: client}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			var identitySchemaResp resource.IdentitySchemaResponse
+			r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+			resp := resource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+				Identity: &tfsdk.ResourceIdentity{
+					Schema: identitySchemaResp.IdentitySchema,
+					Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			r.ImportState(ctx, resource.ImportStateRequest{ID: test.importId}, &resp)
+
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Fatalf("ImportState(%q) diagnostics = %v, wantErr %v", test.importId, resp.Diagnostics, test.wantErr)
+			}
+
+			if test.wantErr {
+				return
+			}
+
+			var id, importedVaultId types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("vault_id"), &importedVaultId)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to read imported state: %v", resp.Diagnostics)
+			}
+
+			if id.ValueString() != entryId || importedVaultId.ValueString() != vaultId {
+				t.Errorf("ImportState(%q) = %s, %s, want %s, %s", test.importId, id, importedVaultId, entryId, vaultId)
+			}
+		})
+	}
+}
+
+// testDvlsClient returns a dvls client logged in to a test server answering GET requests on the paths of
+// responses with their body, and with a not found result otherwise.
+func testDvlsClient(t *testing.T, responses map[string]string) *dvls.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/login/partial":
+			fmt.Fprint(w, `{"result": 1, "data": {"result": 1, "tokenId": "token"}}`)
+		case r.URL.Path == "/api/is-logged":
+			fmt.Fprint(w, "true")
+		case responses[r.URL.Path] != "":
+			fmt.Fprint(w, responses[r.URL.Path])
+		default:
+			fmt.Fprintf(w, `{"result": %d}`, dvls.SaveResultNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := dvls.NewClient("app id", "app secret", server.URL)
+	if err != nil {
+		t.Fatalf("unable to create dvls client: %s", err)
+	}
+
+	return &client
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// entryPathSeparator is the separator DVLS uses between the folders of an entry path.
+const entryPathSeparator = `\`
+
+// splitEntryPath splits path on "\", trims the spaces around each segment and drops empty segments.
+// "/" is not a separator, DVLS allows it in vault, folder and entry names.
+func splitEntryPath(path string) []string {
+	var result []string
+	for _, segment := range strings.Split(path, entryPathSeparator) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			result = append(result, segment)
+		}
+	}
+
+	return result
+}
+
+// normalizeFolder returns folder in the form DVLS stores entry folder paths, ex.: "foo\bar".
+func normalizeFolder(folder string) string {
+	return strings.Join(splitEntryPath(folder), entryPathSeparator)
+}
+
+// newEntryPath joins the vault, folder and name of an entry into "<vault>\<folder>\<name>".
+func newEntryPath(vault string, folder string, name string) (string, error) {
+	vaultSegments := splitEntryPath(vault)
+	if len(vaultSegments) != 1 {
+		return "", errors.New("vault must be a single non-empty path segment")
+	}
+
+	nameSegments := splitEntryPath(name)
+	if len(nameSegments) != 1 {
+		return "", errors.New("name must be a single non-empty path segment")
+	}
+
+	segments := append(vaultSegments, splitEntryPath(folder)...)
+	segments = append(segments, nameSegments...)
+
+	return strings.Join(segments, entryPathSeparator), nil
+}
+
+// parseEntryPath splits an entry path built by newEntryPath into its vault, folder and name. The
+// folder is empty when the entry is at the root of the vault.
+func parseEntryPath(path string) (string, string, string, error) {
+	segments := splitEntryPath(path)
+	if len(segments) < 2 {
+		return "", "", "", errors.New("entry path must hold at least a vault and a name, ex.: vault\\folder\\name")
+	}
+
+	vault := segments[0]
+	folder := strings.Join(segments[1:len(segments)-1], entryPathSeparator)
+	name := segments[len(segments)-1]
+
+	return vault, folder, name, nil
+}
+
+// isUUID returns whether s is a UUID in its canonical form, ex.: 00000000-0000-0000-0000-000000000000.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	_, err := uuid.Parse(s)
+	return err == nil
+}
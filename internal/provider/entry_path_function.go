@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &EntryPathFunction{}
+
+func NewEntryPathFunction() function.Function {
+	return &EntryPathFunction{}
+}
+
+// EntryPathFunction defines the function implementation.
+type EntryPathFunction struct{}
+
+func (f *EntryPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "entry_path"
+}
+
+func (f *EntryPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build an entry path",
+		Description: "Builds the path of an entry from its vault, folder and name, ex.: vault\\folder\\name. The folder is normalized, and can be empty for entries at the root of the vault. / is not a separator, as DVLS allows it in names.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "vault",
+				Description: "Vault name",
+			},
+			function.StringParameter{
+				Name:        "folder",
+				Description: "Entry folder path, using \\ as separator",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "Entry name",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EntryPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var vault, folder, name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &vault, &folder, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	entryPath, err := newEntryPath(vault, folder, name)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, entryPath))
+}
@@ -0,0 +1,113 @@
+package provider
+
+import "testing"
+
+func TestNormalizeFolder(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		`foo\bar`:          `foo\bar`,
+		"foo/bar":          "foo/bar",
+		`\foo\\bar\`:       `foo\bar`,
+		` foo \ bar baz \`: `foo\bar baz`,
+	}
+
+	for folder, expected := range tests {
+		if actual := normalizeFolder(folder); actual != expected {
+			t.Errorf("normalizeFolder(%q) = %q, expected %q", folder, actual, expected)
+		}
+	}
+}
+
+func TestEntryPath(t *testing.T) {
+	entryPath, err := newEntryPath("vault", `\foo\bar\`, "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if entryPath != `vault\foo\bar\name` {
+		t.Errorf("unexpected entry path %q", entryPath)
+	}
+
+	vault, folder, name, err := parseEntryPath(entryPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if vault != "vault" || folder != `foo\bar` || name != "name" {
+		t.Errorf("unexpected parsed entry path %q, %q, %q", vault, folder, name)
+	}
+
+	entryPath, err = newEntryPath("vault", "", "name")
+	if err != nil || entryPath != `vault\name` {
+		t.Errorf("unexpected entry path %q, error: %v", entryPath, err)
+	}
+
+	if _, err := newEntryPath("vault", "foo", `bar\name`); err == nil {
+		t.Error("expected an error for a name holding a separator")
+	}
+
+	entryPath, err = newEntryPath("prod/eu", `foo\bar`, "prod/db")
+	if err != nil || entryPath != `prod/eu\foo\bar\prod/db` {
+		t.Errorf("unexpected entry path %q with / in names, error: %v", entryPath, err)
+	}
+
+	vault, folder, name, err = parseEntryPath(`prod/eu\foo/bar\prod/db`)
+	if err != nil || vault != "prod/eu" || folder != "foo/bar" || name != "prod/db" {
+		t.Errorf("unexpected parsed entry path %q, %q, %q with / in names, error: %v", vault, folder, name, err)
+	}
+
+	if _, err := newEntryPath("", "foo", "name"); err == nil {
+		t.Error("expected an error for an empty vault")
+	}
+
+	if _, _, _, err := parseEntryPath("name"); err == nil {
+		t.Error("expected an error for a path without vault")
+	}
+}
+
+func TestEntryPathRoundTrip(t *testing.T) {
+	tests := []struct {
+		vault  string
+		folder string
+		name   string
+	}{
+		{vault: "vault", folder: "", name: "name"},
+		{vault: "vault", folder: `foo\bar`, name: "name"},
+		{vault: "prod/eu", folder: `a/b\c`, name: "prod/db"},
+	}
+
+	for _, test := range tests {
+		entryPath, err := newEntryPath(test.vault, test.folder, test.name)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		vault, folder, name, err := parseEntryPath(entryPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if vault != test.vault || folder != test.folder || name != test.name {
+			t.Errorf("parseEntryPath(%q) = %q, %q, %q, expected %q, %q, %q", entryPath, vault, folder, name, test.vault, test.folder, test.name)
+		}
+
+		if folder != normalizeFolder(test.folder) {
+			t.Errorf("parsed folder %q differs from normalizeFolder(%q)", folder, test.folder)
+		}
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	tests := map[string]bool{
+		"00000000-0000-0000-0000-000000000000":   true,
+		"{00000000-0000-0000-0000-000000000000}": false,
+		"00000000000000000000000000000000":       false,
+		"not-a-uuid":                             false,
+	}
+
+	for value, expected := range tests {
+		if actual := isUUID(value); actual != expected {
+			t.Errorf("isUUID(%q) = %t, expected %t", value, actual, expected)
+		}
+	}
+}
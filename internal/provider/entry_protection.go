@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkEntryDeletionProtection adds an error to diags if the entry cannot be deleted, either because
+// deletion_protection is enabled or because the live entry carries one of the provider protected tags.
+func checkEntryDeletionProtection(entryName string, entryId string, deletionProtection types.Bool, entryTags []string, protectedTags []string, diags *diag.Diagnostics) {
+	if deletionProtection.ValueBool() {
+		diags.AddError(
+			"entry is protected against deletion",
+			fmt.Sprintf("Entry %s (%s) has deletion_protection enabled. Set deletion_protection to false and apply the change before destroying it.", entryName, entryId),
+		)
+		return
+	}
+
+	for _, tag := range entryTags {
+		for _, protectedTag := range protectedTags {
+			if strings.EqualFold(tag, protectedTag) {
+				diags.AddError(
+					"entry is protected against deletion",
+					fmt.Sprintf("Entry %s (%s) carries the protected tag %q in DVLS. Remove the tag from the entry, or from the provider protected_tags, before destroying it.", entryName, entryId, tag),
+				)
+				return
+			}
+		}
+	}
+}
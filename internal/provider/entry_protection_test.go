@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckEntryDeletionProtection(t *testing.T) {
+	tests := map[string]struct {
+		deletionProtection types.Bool
+		entryTags          []string
+		protectedTags      []string
+		expectError        bool
+	}{
+		"deletion protection":  {deletionProtection: types.BoolValue(true), expectError: true},
+		"protected tag":        {deletionProtection: types.BoolValue(false), entryTags: []string{"foo", "Production"}, protectedTags: []string{"production"}, expectError: true},
+		"no protected tag":     {deletionProtection: types.BoolValue(false), entryTags: []string{"foo"}, protectedTags: []string{"production"}, expectError: false},
+		"empty protected tags": {deletionProtection: types.BoolValue(false), entryTags: []string{"production"}, protectedTags: nil, expectError: false},
+		"null protection flag": {deletionProtection: types.BoolNull(), entryTags: []string{"foo"}, protectedTags: []string{"production"}, expectError: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			checkEntryDeletionProtection("entry", "00000000-0000-0000-0000-000000000000", test.deletionProtection, test.entryTags, test.protectedTags, &diags)
+
+			if diags.HasError() != test.expectError {
+				t.Errorf("HasError = %t, expected %t: %v", diags.HasError(), test.expectError, diags)
+			}
+		})
+	}
+}
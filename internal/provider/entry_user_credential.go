@@ -33,6 +33,12 @@ func setEntryUserCredentialResourceModel(entryusercredential dvls.EntryUserCrede
 	model.Id = basetypes.NewStringValue(entryusercredential.ID)
 	model.VaultId = basetypes.NewStringValue(entryusercredential.VaultId)
 	model.Name = basetypes.NewStringValue(entryusercredential.EntryName)
+	model.PasswordTemplate = data.PasswordTemplate
+	model.DeletionProtection = data.DeletionProtection
+
+	if model.DeletionProtection.IsNull() {
+		model.DeletionProtection = basetypes.NewBoolValue(false)
+	}
 
 	if entryusercredential.Credentials.Password != nil && *entryusercredential.Credentials.Password != "" {
 		model.Password = basetypes.NewStringValue(*entryusercredential.Credentials.Password)
@@ -104,6 +104,8 @@ func (d *EntryUserCredentialDataSource) Configure(ctx context.Context, req datas
 }
 
 func (d *EntryUserCredentialDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var data *EntryUserCredentialDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -111,13 +113,17 @@ func (d *EntryUserCredentialDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
+	logResult := logDvlsCall(ctx, "Get", "user_credential", "", data.Id.ValueString())
 	entryusercredential, err := d.client.Entries.UserCredential.Get(data.Id.ValueString())
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read user credential entry", err.Error())
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetUserAuthDetails", "user_credential", entryusercredential.VaultId, entryusercredential.ID)
 	entryusercredential, err = d.client.Entries.UserCredential.GetUserAuthDetails(entryusercredential)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read user credential entry sensitive information", err.Error())
 		return
@@ -6,17 +6,25 @@ import (
 	"strings"
 
 	"github.com/Devolutions/go-dvls"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &EntryUserCredentialResource{}
 var _ resource.ResourceWithImportState = &EntryUserCredentialResource{}
+var _ resource.ResourceWithIdentity = &EntryUserCredentialResource{}
+var _ resource.ResourceWithModifyPlan = &EntryUserCredentialResource{}
 
 func NewEntryUserCredentialResource() resource.Resource {
 	return &EntryUserCredentialResource{}
@@ -24,7 +32,8 @@ func NewEntryUserCredentialResource() resource.Resource {
 
 // EntryUserCredentialResource defines the resource implementation.
 type EntryUserCredentialResource struct {
-	client *dvls.Client
+	client        *dvls.Client
+	protectedTags []string
 }
 
 // EntryUserCredentialResourceModel describes the resource data model.
@@ -37,6 +46,9 @@ type EntryUserCredentialResourceModel struct {
 	Password    types.String   `tfsdk:"password"`
 	Folder      types.String   `tfsdk:"folder"`
 	Tags        []types.String `tfsdk:"tags"`
+
+	PasswordTemplate   *PasswordPolicyModel `tfsdk:"password_template"`
+	DeletionProtection types.Bool           `tfsdk:"deletion_protection"`
 }
 
 func (r *EntryUserCredentialResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,9 +83,55 @@ func (r *EntryUserCredentialResource) Schema(ctx context.Context, req resource.S
 				Optional:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "User Credential password",
+				Description: "User Credential password. Generated from password_template when not set.",
 				Optional:    true,
+				Computed:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password_template")),
+				},
+			},
+			"password_template": schema.SingleNestedAttribute{
+				Description: "Generate the password locally from this template instead of setting password. " +
+					"The password is regenerated when it no longer meets the template. Null attributes are not enforced.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"min_length": schema.Int64Attribute{
+						Description: "Length of the generated password, defaults to 16 or max_length when lower",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"max_length": schema.Int64Attribute{
+						Description: "Maximum password length",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"min_lowercase": schema.Int64Attribute{
+						Description: "Minimum number of lowercase characters",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"min_uppercase": schema.Int64Attribute{
+						Description: "Minimum number of uppercase characters",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"min_digits": schema.Int64Attribute{
+						Description: "Minimum number of digits",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"min_symbols": schema.Int64Attribute{
+						Description: "Minimum number of symbols",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"forbidden_substrings": schema.ListAttribute{
+						ElementType: types.StringType,
+						Description: "Substrings the password must not contain, case-insensitive",
+						Optional:    true,
+					},
+				},
 			},
 			"folder": schema.StringAttribute{
 				Description: "User Credential folder path",
@@ -84,31 +142,108 @@ func (r *EntryUserCredentialResource) Schema(ctx context.Context, req resource.S
 				Description: "User Credential tags",
 				Optional:    true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Prevent the user credential from being deleted. Must be set to false and applied before the entry can be destroyed.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
+func (r *EntryUserCredentialResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
 func (r *EntryUserCredentialResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*dvls.Client)
+	data, ok := req.ProviderData.(*DvlsResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dvls.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DvlsResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.protectedTags = data.ProtectedTags
+}
+
+func (r *EntryUserCredentialResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var password types.String
+	var passwordTemplate types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_template"), &passwordTemplate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A configured password is planned as is.
+	if !password.IsNull() {
+		return
+	}
+
+	// Without a template, an unset password stays null so removing it from the configuration still clears it.
+	if passwordTemplate.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringNull())...)
+		return
+	}
+
+	// The password stays unknown until the whole template is known, as unknown template attributes
+	// would otherwise be read as not enforced.
+	template, err := passwordTemplate.ToTerraformValue(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("password_template"), "unable to read password template", err.Error())
+		return
+	}
+
+	if !template.IsFullyKnown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+		return
+	}
+
+	var policy PasswordPolicyModel
+
+	resp.Diagnostics.Append(passwordTemplate.As(ctx, &policy, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var statePassword types.String
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password"), &statePassword)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Keep the current password as long as it meets the template, otherwise generate a new one on apply.
+	if !statePassword.IsNull() && passwordMeetsPolicy(statePassword.ValueString(), policy) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), statePassword)...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
 }
 
 func (r *EntryUserCredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var plan *EntryUserCredentialResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -116,10 +251,21 @@ func (r *EntryUserCredentialResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
+	if plan.Password.IsUnknown() {
+		generatePlanPassword(plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	ctx = maskDvlsLogSecrets(ctx, plan.Password.ValueString())
+
 	userDetails := r.client.Entries.UserCredential.NewUserAuthDetails(plan.Username.ValueString(), plan.Password.ValueString())
 	entryusercredential := newEntryUserCredentialFromResourceModel(plan, userDetails)
 
+	logResult := logDvlsCall(ctx, "New", "user_credential", entryusercredential.VaultId, entryusercredential.ID)
 	entryusercredential, err := r.client.Entries.UserCredential.New(entryusercredential)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to create user credential entry", err.Error())
 		return
@@ -128,9 +274,12 @@ func (r *EntryUserCredentialResource) Create(ctx context.Context, req resource.C
 	setEntryUserCredentialResourceModel(entryusercredential, plan)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, plan.VaultId, plan.Id)...)
 }
 
 func (r *EntryUserCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var state *EntryUserCredentialResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -138,10 +287,14 @@ func (r *EntryUserCredentialResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
+	ctx = maskDvlsLogSecrets(ctx, state.Password.ValueString())
+
 	userDetails := r.client.Entries.UserCredential.NewUserAuthDetails(state.Username.ValueString(), state.Password.ValueString())
 	entryusercredential := newEntryUserCredentialFromResourceModel(state, userDetails)
 
+	logResult := logDvlsCall(ctx, "Get", "user_credential", entryusercredential.VaultId, entryusercredential.ID)
 	entryusercredential, err := r.client.Entries.UserCredential.Get(entryusercredential.ID)
+	logResult(err)
 	if err != nil {
 		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
 			resp.State.RemoveResource(ctx)
@@ -151,7 +304,9 @@ func (r *EntryUserCredentialResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetUserAuthDetails", "user_credential", entryusercredential.VaultId, entryusercredential.ID)
 	entryusercredential, err = r.client.Entries.UserCredential.GetUserAuthDetails(entryusercredential)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read user credential entry sensitive information", err.Error())
 		return
@@ -160,9 +315,12 @@ func (r *EntryUserCredentialResource) Read(ctx context.Context, req resource.Rea
 	setEntryUserCredentialResourceModel(entryusercredential, state)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, state.VaultId, state.Id)...)
 }
 
 func (r *EntryUserCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var plan *EntryUserCredentialResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -170,19 +328,33 @@ func (r *EntryUserCredentialResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	if plan.Password.IsUnknown() {
+		generatePlanPassword(plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	ctx = maskDvlsLogSecrets(ctx, plan.Password.ValueString())
+
 	userDetails := r.client.Entries.UserCredential.NewUserAuthDetails(plan.Username.ValueString(), plan.Password.ValueString())
 	entryusercredential := newEntryUserCredentialFromResourceModel(plan, userDetails)
 
+	logResult := logDvlsCall(ctx, "Update", "user_credential", entryusercredential.VaultId, entryusercredential.ID)
 	_, err := r.client.Entries.UserCredential.Update(entryusercredential)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to update user credential entry", err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, plan.VaultId, plan.Id)...)
 }
 
 func (r *EntryUserCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var state *EntryUserCredentialResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -190,7 +362,26 @@ func (r *EntryUserCredentialResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
-	err := r.client.Entries.UserCredential.Delete(state.Id.ValueString())
+	logResult := logDvlsCall(ctx, "Get", "user_credential", state.VaultId.ValueString(), state.Id.ValueString())
+	entryusercredential, err := r.client.Entries.UserCredential.Get(state.Id.ValueString())
+	logResult(err)
+	if err != nil {
+		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("unable to read user credential entry", err.Error())
+		return
+	}
+
+	checkEntryDeletionProtection(entryusercredential.EntryName, entryusercredential.ID, state.DeletionProtection, entryusercredential.Tags, r.protectedTags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logResult = logDvlsCall(ctx, "Delete", "user_credential", state.VaultId.ValueString(), state.Id.ValueString())
+	err = r.client.Entries.UserCredential.Delete(state.Id.ValueString())
+	logResult(err)
 	if err != nil {
 		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
 			resp.State.RemoveResource(ctx)
@@ -202,5 +393,50 @@ func (r *EntryUserCredentialResource) Delete(ctx context.Context, req resource.D
 }
 
 func (r *EntryUserCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx = newDvlsLogContext(ctx)
+
+	importId, diags := getEntryImportId(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaultId, entryId, err := parseEntryImportId(importId)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to import user credential entry", err.Error())
+		return
+	}
+
+	logResult := logDvlsCall(ctx, "Get", "user_credential", vaultId, entryId)
+	entryusercredential, err := r.client.Entries.UserCredential.Get(entryId)
+	logResult(err)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to import user credential entry", err.Error())
+		return
+	}
+
+	if vaultId != "" && !strings.EqualFold(entryusercredential.VaultId, vaultId) {
+		resp.Diagnostics.AddError("unable to import user credential entry", fmt.Sprintf("entry %s belongs to vault %s, not %s", entryId, entryusercredential.VaultId, vaultId))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), entryusercredential.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vault_id"), entryusercredential.VaultId)...)
+	resp.Diagnostics.Append(setEntryIdentity(ctx, resp.Identity, types.StringValue(entryusercredential.VaultId), types.StringValue(entryusercredential.ID))...)
+}
+
+// generatePlanPassword sets the password of plan, generated from its password template.
+func generatePlanPassword(plan *EntryUserCredentialResourceModel, diags *diag.Diagnostics) {
+	if plan.PasswordTemplate == nil {
+		plan.Password = types.StringNull()
+		return
+	}
+
+	password, err := generatePassword(*plan.PasswordTemplate)
+	if err != nil {
+		diags.AddAttributeError(path.Root("password_template"), "unable to generate user credential password", err.Error())
+		return
+	}
+
+	plan.Password = types.StringValue(password)
 }
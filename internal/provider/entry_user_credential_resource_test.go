@@ -1,9 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -52,3 +59,82 @@ resource "dvls_EntryUserCredential" "test" {
 }
 `, configurableAttribute)
 }
+
+func TestEntryUserCredentialResourceModifyPlan(t *testing.T) {
+	template := func(minLength types.Int64, forbiddenSubstrings types.List) types.Object {
+		return types.ObjectValueMust(PasswordPolicyModel{}.AttributeTypes(), map[string]attr.Value{
+			"min_length":           minLength,
+			"max_length":           types.Int64Null(),
+			"min_lowercase":        types.Int64Null(),
+			"min_uppercase":        types.Int64Null(),
+			"min_digits":           types.Int64Null(),
+			"min_symbols":          types.Int64Null(),
+			"forbidden_substrings": forbiddenSubstrings,
+		})
+	}
+
+	noSubstrings := types.ListNull(types.StringType)
+
+	tests := map[string]struct {
+		template types.Object
+		expected types.String
+	}{
+		"met template":                 {template: template(types.Int64Value(3), noSubstrings), expected: types.StringValue("abc")},
+		"unmet template":               {template: template(types.Int64Value(4), noSubstrings), expected: types.StringUnknown()},
+		"unknown template":             {template: types.ObjectUnknown(PasswordPolicyModel{}.AttributeTypes()), expected: types.StringUnknown()},
+		"unknown min_length":           {template: template(types.Int64Unknown(), noSubstrings), expected: types.StringUnknown()},
+		"unknown forbidden_substrings": {template: template(types.Int64Value(3), types.ListUnknown(types.StringType)), expected: types.StringUnknown()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			plan := testEntryUserCredentialState(t, map[string]attr.Value{"password_template": test.template})
+			state := testEntryUserCredentialState(t, map[string]attr.Value{"password": types.StringValue("abc")})
+
+			req := fwresource.ModifyPlanRequest{Config: tfsdk.Config(plan), Plan: tfsdk.Plan(plan), State: state}
+			resp := fwresource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+
+			NewEntryUserCredentialResource().(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var password types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("password"), &password)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to read planned password: %v", resp.Diagnostics)
+			}
+
+			if !password.Equal(test.expected) {
+				t.Errorf("planned password %s, expected %s", password, test.expected)
+			}
+		})
+	}
+}
+
+// testEntryUserCredentialState returns a user credential state of the resource schema with the given
+// attributes set and every other attribute null.
+func testEntryUserCredentialState(t *testing.T, attributes map[string]attr.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	NewEntryUserCredentialResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	for name, value := range attributes {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unable to build state: %v", diags)
+		}
+	}
+
+	return state
+}
@@ -114,6 +114,8 @@ func (d *EntryWebsiteDataSource) Configure(ctx context.Context, req datasource.C
 }
 
 func (d *EntryWebsiteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var data EntryWebsiteDataSourceModel
 
 	diags := req.Config.Get(ctx, &data)
@@ -122,7 +124,9 @@ func (d *EntryWebsiteDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	logResult := logDvlsCall(ctx, "Get", "website", "", data.Id.ValueString())
 	entryWebsite, err := d.client.Entries.Website.Get(data.Id.ValueString())
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Website Entry",
@@ -131,7 +135,9 @@ func (d *EntryWebsiteDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	logResult = logDvlsCall(ctx, "GetWebsiteDetails", "website", entryWebsite.VaultId, entryWebsite.ID)
 	entryWebsiteSensitiveData, err := d.client.Entries.Website.GetWebsiteDetails(entryWebsite)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Website Entry Sensitive Data",
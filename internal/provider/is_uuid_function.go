@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &IsUUIDFunction{}
+
+func NewIsUUIDFunction() function.Function {
+	return &IsUUIDFunction{}
+}
+
+// IsUUIDFunction defines the function implementation.
+type IsUUIDFunction struct{}
+
+func (f *IsUUIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_uuid"
+}
+
+func (f *IsUUIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check if a string is a UUID",
+		Description: "Returns true if the string is a UUID in its canonical form, ex.: 00000000-0000-0000-0000-000000000000, as used by vault and entry IDs.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "String to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *IsUUIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, isUUID(value)))
+}
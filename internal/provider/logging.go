@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Devolutions/go-dvls"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// dvlsLogSubsystem is the tflog subsystem of the dvls client calls. Its level follows
+// TF_LOG_PROVIDER_DVLS and can be set on its own with TF_LOG_PROVIDER_DVLS_CLIENT.
+const dvlsLogSubsystem = "client"
+
+// dvlsLogMaskedFieldKeys are the log field keys whose values are always masked.
+var dvlsLogMaskedFieldKeys = []string{"app_secret", "password", "master_password", "token", "private_key", "content"}
+
+// dvlsLogSecretPatterns match the secrets that can be echoed in dvls client errors, such as request
+// bodies and authorization headers. They are masked in every logged field value.
+var dvlsLogSecretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)"?\w*(password|secret|token|key)\w*"?\s*[:=]\s*("[^"]*"|[^\s,&}]+)`),
+	regexp.MustCompile(`(?i)bearer\s+\S+`),
+}
+
+// dvlsResultCategories are the go-dvls save results reported as result category in the logs.
+var dvlsResultCategories = []struct {
+	result   dvls.SaveResult
+	category string
+}{
+	{dvls.SaveResultNotFound, "not_found"},
+	{dvls.SaveResultAccessDenied, "access_denied"},
+	{dvls.SaveResultInvalidData, "invalid_data"},
+	{dvls.SaveResultAlreadyExists, "already_exists"},
+	{dvls.SaveResultLicenseExpired, "license_expired"},
+}
+
+// newDvlsLogContext returns ctx with the dvls client logging subsystem. It is called once at the start
+// of each request, before any logDvlsCall.
+func newDvlsLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, dvlsLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_DVLS_CLIENT"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, dvlsLogSubsystem, dvlsLogMaskedFieldKeys...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, dvlsLogSubsystem, dvlsLogSecretPatterns...)
+
+	return ctx
+}
+
+// maskDvlsLogSecrets returns ctx masking secrets in every field value logged by the dvls client
+// logging subsystem. Empty secrets are ignored.
+func maskDvlsLogSecrets(ctx context.Context, secrets ...string) context.Context {
+	var masked []string
+	for _, secret := range secrets {
+		if secret != "" {
+			masked = append(masked, secret)
+		}
+	}
+
+	if len(masked) == 0 {
+		return ctx
+	}
+
+	return tflog.SubsystemMaskAllFieldValuesStrings(ctx, dvlsLogSubsystem, masked...)
+}
+
+// logDvlsCall logs the start of a dvls client call and returns the function logging its result. The
+// entry type is empty for vault calls, and the vault and entry IDs are empty when not known yet. ctx
+// must hold the dvls client logging subsystem, see newDvlsLogContext.
+func logDvlsCall(ctx context.Context, method string, entryType string, vaultId string, entryId string) func(err error) {
+	fields := map[string]interface{}{
+		"method": method,
+	}
+
+	if entryType != "" {
+		fields["entry_type"] = entryType
+	}
+
+	if vaultId != "" {
+		fields["vault_id"] = vaultId
+	}
+
+	if entryId != "" {
+		fields["entry_id"] = entryId
+	}
+
+	tflog.SubsystemTrace(ctx, dvlsLogSubsystem, "calling dvls", fields)
+
+	start := time.Now()
+
+	return func(err error) {
+		fields["duration_ms"] = time.Since(start).Milliseconds()
+		fields["result"] = dvlsResultCategory(err)
+
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+
+		tflog.SubsystemDebug(ctx, dvlsLogSubsystem, "called dvls", fields)
+	}
+}
+
+// dvlsResultCategory returns the category of the result of a dvls client call, from its error.
+func dvlsResultCategory(err error) string {
+	if err == nil {
+		return "success"
+	}
+
+	for _, c := range dvlsResultCategories {
+		if strings.Contains(err.Error(), c.result.String()) {
+			return c.category
+		}
+	}
+
+	return "error"
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Devolutions/go-dvls"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestDvlsResultCategory(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"success":       {err: nil, expected: "success"},
+		"not found":     {err: errors.New("unexpected result code " + dvls.SaveResultNotFound.String()), expected: "not_found"},
+		"access denied": {err: errors.New("unexpected result code " + dvls.SaveResultAccessDenied.String()), expected: "access_denied"},
+		"other":         {err: errors.New("connection refused"), expected: "error"},
+	}
+
+	for name, test := range tests {
+		if actual := dvlsResultCategory(test.err); actual != test.expected {
+			t.Errorf("%s: dvlsResultCategory() = %q, expected %q", name, actual, test.expected)
+		}
+	}
+}
+
+func TestLogDvlsCallMasksSecrets(t *testing.T) {
+	tests := map[string]error{
+		"known secret":    errors.New("login failed for s3cr3t"),
+		"request body":    errors.New(`unexpected result code 3 (InvalidData) {"password":"s3cr3t"}`),
+		"request header":  errors.New("unexpected status code 401, Authorization: Bearer s3cr3t"),
+		"query parameter": errors.New("failed to make request to https://dvls.test/api?token=s3cr3t"),
+	}
+
+	for name, callErr := range tests {
+		t.Run(name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+			ctx = newDvlsLogContext(ctx)
+			ctx = maskDvlsLogSecrets(ctx, "", "s3cr3t")
+
+			logDvlsCall(ctx, "Get", "", "vault", "")(callErr)
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatalf("unable to decode logs: %s", err)
+			}
+
+			if len(entries) != 2 {
+				t.Fatalf("expected 2 log entries, got %d: %v", len(entries), entries)
+			}
+
+			logged, ok := entries[1]["error"].(string)
+			if !ok {
+				t.Fatalf("expected an error field, got %v", entries[1])
+			}
+
+			if strings.Contains(logged, "s3cr3t") || !strings.Contains(logged, "***") {
+				t.Errorf("secret not masked in logged error %q", logged)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeFolderFunction{}
+
+func NewNormalizeFolderFunction() function.Function {
+	return &NormalizeFolderFunction{}
+}
+
+// NormalizeFolderFunction defines the function implementation.
+type NormalizeFolderFunction struct{}
+
+func (f *NormalizeFolderFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_folder"
+}
+
+func (f *NormalizeFolderFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a folder path",
+		Description: "Returns a folder path in the form DVLS stores it: segments separated by \\, without leading, trailing or repeated separators and without spaces around segments. / is not a separator, as DVLS allows it in folder names.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "folder",
+				Description: "Folder path",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeFolderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var folder string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &folder))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalizeFolder(folder)))
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseEntryPathFunction{}
+
+func NewParseEntryPathFunction() function.Function {
+	return &ParseEntryPathFunction{}
+}
+
+// ParseEntryPathFunction defines the function implementation.
+type ParseEntryPathFunction struct{}
+
+// ParseEntryPathFunctionModel describes the function result.
+type ParseEntryPathFunctionModel struct {
+	Vault  string `tfsdk:"vault"`
+	Folder string `tfsdk:"folder"`
+	Name   string `tfsdk:"name"`
+}
+
+func (f *ParseEntryPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_entry_path"
+}
+
+func (f *ParseEntryPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse an entry path",
+		Description: "Splits an entry path, ex.: vault\\folder\\name, into an object with its vault, folder and name. The folder is normalized, and empty for entries at the root of the vault. / is not a separator, as DVLS allows it in names.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "path",
+				Description: "Entry path, using \\ as separator",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"vault":  types.StringType,
+				"folder": types.StringType,
+				"name":   types.StringType,
+			},
+		},
+	}
+}
+
+func (f *ParseEntryPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var entryPath string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &entryPath))
+	if resp.Error != nil {
+		return
+	}
+
+	vault, folder, name, err := parseEntryPath(entryPath)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, ParseEntryPathFunctionModel{
+		Vault:  vault,
+		Folder: folder,
+		Name:   name,
+	}))
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PasswordMeetsPolicyFunction{}
+
+func NewPasswordMeetsPolicyFunction() function.Function {
+	return &PasswordMeetsPolicyFunction{}
+}
+
+// PasswordMeetsPolicyFunction defines the function implementation.
+type PasswordMeetsPolicyFunction struct{}
+
+func (f *PasswordMeetsPolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "password_meets_policy"
+}
+
+func (f *PasswordMeetsPolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check a password against a policy",
+		MarkdownDescription: "Returns true if the password satisfies every rule of the policy. " +
+			"The policy is an object with the `min_length`, `max_length`, `min_lowercase`, `min_uppercase`, `min_digits`, `min_symbols` and `forbidden_substrings` attributes. " +
+			"All attributes must be set, null attributes are not enforced. " +
+			"Lengths are counted in characters, forbidden substrings are matched case-insensitively and any character that is not a letter or a digit counts as a symbol.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "password",
+				Description: "Password to check",
+			},
+			function.ObjectParameter{
+				Name:           "policy",
+				Description:    "Password policy",
+				AttributeTypes: PasswordPolicyModel{}.AttributeTypes(),
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *PasswordMeetsPolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var password string
+	var policy PasswordPolicyModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &password, &policy))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, passwordMeetsPolicy(password, policy)))
+}
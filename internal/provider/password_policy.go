@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PasswordPolicyModel describes a password policy. Null attributes are not enforced.
+type PasswordPolicyModel struct {
+	MinLength           types.Int64    `tfsdk:"min_length"`
+	MaxLength           types.Int64    `tfsdk:"max_length"`
+	MinLowercase        types.Int64    `tfsdk:"min_lowercase"`
+	MinUppercase        types.Int64    `tfsdk:"min_uppercase"`
+	MinDigits           types.Int64    `tfsdk:"min_digits"`
+	MinSymbols          types.Int64    `tfsdk:"min_symbols"`
+	ForbiddenSubstrings []types.String `tfsdk:"forbidden_substrings"`
+}
+
+func (p PasswordPolicyModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"min_length":           types.Int64Type,
+		"max_length":           types.Int64Type,
+		"min_lowercase":        types.Int64Type,
+		"min_uppercase":        types.Int64Type,
+		"min_digits":           types.Int64Type,
+		"min_symbols":          types.Int64Type,
+		"forbidden_substrings": types.ListType{ElemType: types.StringType},
+	}
+}
+
+// passwordMeetsPolicy returns whether password satisfies every rule of policy. Lengths are counted
+// in characters, and forbidden substrings are matched case-insensitively. Any character that is not
+// a letter or a digit counts as a symbol.
+func passwordMeetsPolicy(password string, policy PasswordPolicyModel) bool {
+	length := int64(utf8.RuneCountInString(password))
+
+	if !policy.MinLength.IsNull() && length < policy.MinLength.ValueInt64() {
+		return false
+	}
+
+	if !policy.MaxLength.IsNull() && length > policy.MaxLength.ValueInt64() {
+		return false
+	}
+
+	var lowercase, uppercase, digits, symbols int64
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lowercase++
+		case unicode.IsUpper(r):
+			uppercase++
+		case unicode.IsDigit(r):
+			digits++
+		case !unicode.IsLetter(r):
+			symbols++
+		}
+	}
+
+	if lowercase < policy.MinLowercase.ValueInt64() ||
+		uppercase < policy.MinUppercase.ValueInt64() ||
+		digits < policy.MinDigits.ValueInt64() ||
+		symbols < policy.MinSymbols.ValueInt64() {
+		return false
+	}
+
+	for _, forbidden := range policy.ForbiddenSubstrings {
+		if forbidden.ValueString() != "" && strings.Contains(strings.ToLower(password), strings.ToLower(forbidden.ValueString())) {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	passwordLowercase = "abcdefghijklmnopqrstuvwxyz"
+	passwordUppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits    = "0123456789"
+	passwordSymbols   = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+
+	// defaultGeneratedPasswordLength is the length of generated passwords when the policy has no
+	// minimum length.
+	defaultGeneratedPasswordLength = 16
+
+	// maxPasswordGenerationAttempts bounds the retries when generated passwords hold a forbidden substring.
+	maxPasswordGenerationAttempts = 100
+)
+
+// generatePassword returns a random password that meets policy. The password is as long as the policy
+// minimum length, or defaultGeneratedPasswordLength capped to the maximum length when unset, and at
+// least as long as the sum of the character class minimums.
+func generatePassword(policy PasswordPolicyModel) (string, error) {
+	classes := []struct {
+		characters string
+		minimum    int64
+	}{
+		{passwordLowercase, policy.MinLowercase.ValueInt64()},
+		{passwordUppercase, policy.MinUppercase.ValueInt64()},
+		{passwordDigits, policy.MinDigits.ValueInt64()},
+		{passwordSymbols, policy.MinSymbols.ValueInt64()},
+	}
+
+	length := int64(defaultGeneratedPasswordLength)
+	if !policy.MinLength.IsNull() {
+		length = policy.MinLength.ValueInt64()
+	} else if !policy.MaxLength.IsNull() {
+		length = min(length, policy.MaxLength.ValueInt64())
+	}
+
+	var required int64
+	for _, class := range classes {
+		required += class.minimum
+	}
+
+	length = max(length, required)
+
+	if !policy.MaxLength.IsNull() && length > policy.MaxLength.ValueInt64() {
+		return "", fmt.Errorf("the policy requires at least %d characters, more than its max_length of %d", length, policy.MaxLength.ValueInt64())
+	}
+
+	if length <= 0 {
+		return "", errors.New("the policy must allow passwords of at least one character")
+	}
+
+	all := passwordLowercase + passwordUppercase + passwordDigits + passwordSymbols
+
+	for range maxPasswordGenerationAttempts {
+		var password []byte
+
+		for _, class := range classes {
+			for range class.minimum {
+				c, err := randomCharacter(class.characters)
+				if err != nil {
+					return "", err
+				}
+				password = append(password, c)
+			}
+		}
+
+		for int64(len(password)) < length {
+			c, err := randomCharacter(all)
+			if err != nil {
+				return "", err
+			}
+			password = append(password, c)
+		}
+
+		// Shuffle so the required characters are not always first.
+		for i := len(password) - 1; i > 0; i-- {
+			j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+			if err != nil {
+				return "", err
+			}
+			password[i], password[j.Int64()] = password[j.Int64()], password[i]
+		}
+
+		if passwordMeetsPolicy(string(password), policy) {
+			return string(password), nil
+		}
+	}
+
+	return "", errors.New("unable to generate a password without the policy forbidden substrings")
+}
+
+func randomCharacter(characters string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+	if err != nil {
+		return 0, err
+	}
+
+	return characters[i.Int64()], nil
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPasswordMeetsPolicy(t *testing.T) {
+	policy := PasswordPolicyModel{
+		MinLength:           types.Int64Value(12),
+		MaxLength:           types.Int64Value(16),
+		MinLowercase:        types.Int64Value(1),
+		MinUppercase:        types.Int64Value(1),
+		MinDigits:           types.Int64Value(2),
+		MinSymbols:          types.Int64Value(1),
+		ForbiddenSubstrings: []types.String{types.StringValue("devolutions")},
+	}
+
+	tests := map[string]bool{
+		"Correct-Horse42":         true,
+		"Short-Horse4":            false,
+		"Correct-Horse-Battery42": false,
+		"correct-horse42":         false,
+		"CORRECT-HORSE42":         false,
+		"Correct-Horse4x":         false,
+		"CorrectxHorse42":         false,
+		"DEVOLUTIONS-a42":         false,
+	}
+
+	for password, expected := range tests {
+		if actual := passwordMeetsPolicy(password, policy); actual != expected {
+			t.Errorf("passwordMeetsPolicy(%q) = %t, expected %t", password, actual, expected)
+		}
+	}
+
+	if !passwordMeetsPolicy("a", PasswordPolicyModel{MinLength: types.Int64Null()}) {
+		t.Error("expected an empty policy to accept any password")
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	policy := PasswordPolicyModel{
+		MinLength:           types.Int64Value(20),
+		MinLowercase:        types.Int64Value(2),
+		MinUppercase:        types.Int64Value(2),
+		MinDigits:           types.Int64Value(2),
+		MinSymbols:          types.Int64Value(2),
+		ForbiddenSubstrings: []types.String{types.StringValue("a")},
+	}
+
+	for range 20 {
+		password, err := generatePassword(policy)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(password) != 20 || !passwordMeetsPolicy(password, policy) {
+			t.Fatalf("generated password %q does not meet the policy", password)
+		}
+	}
+
+	password, err := generatePassword(PasswordPolicyModel{})
+	if err != nil || len(password) != defaultGeneratedPasswordLength {
+		t.Errorf("unexpected default password %q, error: %v", password, err)
+	}
+
+	password, err = generatePassword(PasswordPolicyModel{MaxLength: types.Int64Value(12)})
+	if err != nil || len(password) != 12 {
+		t.Errorf("unexpected password %q capped to max_length, error: %v", password, err)
+	}
+
+	_, err = generatePassword(PasswordPolicyModel{MaxLength: types.Int64Value(4), MinDigits: types.Int64Value(5)})
+	if err == nil {
+		t.Error("expected an error for a policy that cannot be met")
+	}
+}
@@ -2,10 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/Devolutions/go-dvls"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -14,6 +16,7 @@ import (
 
 // Ensure DvlsProvider satisfies various provider interfaces.
 var _ provider.Provider = &DvlsProvider{}
+var _ provider.ProviderWithFunctions = &DvlsProvider{}
 
 // DvlsProvider defines the provider implementation.
 type DvlsProvider struct {
@@ -25,9 +28,19 @@ type DvlsProvider struct {
 
 // DvlsProviderModel describes the provider data model.
 type DvlsProviderModel struct {
-	BaseUri   types.String `tfsdk:"base_uri"`
-	AppId     types.String `tfsdk:"app_id"`
-	AppSecret types.String `tfsdk:"app_secret"`
+	BaseUri       types.String   `tfsdk:"base_uri"`
+	AppId         types.String   `tfsdk:"app_id"`
+	AppSecret     types.String   `tfsdk:"app_secret"`
+	ProtectedTags []types.String `tfsdk:"protected_tags"`
+}
+
+// doctorHint points operators to the doctor subcommand when the provider cannot reach DVLS.
+const doctorHint = "Run \"terraform-provider-dvls doctor\" with DVLS_BASE_URI, DVLS_APP_ID and DVLS_APP_SECRET set to check connectivity, TLS, clock skew and authentication."
+
+// DvlsResourceData describes the data passed to resources by the provider.
+type DvlsResourceData struct {
+	Client        *dvls.Client
+	ProtectedTags []string
 }
 
 func (p *DvlsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -52,11 +65,18 @@ func (p *DvlsProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"protected_tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "Entries carrying any of these tags in DVLS cannot be deleted by Terraform, regardless of their configuration",
+				Optional:    true,
+			},
 		},
 	}
 }
 
 func (p *DvlsProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var data DvlsProviderModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -82,14 +102,26 @@ func (p *DvlsProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	ctx = maskDvlsLogSecrets(ctx, appSecret)
+
+	logResult := logDvlsCall(ctx, "NewClient", "", "", "")
 	dvlsClient, err := dvls.NewClient(appId, appSecret, baseuri)
+	logResult(err)
 	if err != nil {
-		resp.Diagnostics.AddError("unable to set up dvls client", err.Error())
+		resp.Diagnostics.AddError("unable to set up dvls client", fmt.Sprintf("%s\n\n%s", err.Error(), doctorHint))
 		return
 	}
 
+	var protectedTags []string
+	for _, v := range data.ProtectedTags {
+		protectedTags = append(protectedTags, v.ValueString())
+	}
+
 	resp.DataSourceData = &dvlsClient
-	resp.ResourceData = &dvlsClient
+	resp.ResourceData = &DvlsResourceData{
+		Client:        &dvlsClient,
+		ProtectedTags: protectedTags,
+	}
 }
 
 func (p *DvlsProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -111,6 +143,17 @@ func (p *DvlsProvider) DataSources(ctx context.Context) []func() datasource.Data
 	}
 }
 
+func (p *DvlsProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewEntryPathFunction,
+		NewParseEntryPathFunction,
+		NewNormalizeFolderFunction,
+		NewIsUUIDFunction,
+		NewCertificateInfoFunction,
+		NewPasswordMeetsPolicyFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &DvlsProvider{
@@ -1,13 +1,27 @@
 package provider
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/Devolutions/go-dvls"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// vaultMasterPasswordPrivateKey is the private state key holding a hash of the last master password
+// applied by the provider. It is used to detect master password changes, including write-only ones.
+const vaultMasterPasswordPrivateKey = "master_password"
+
+type vaultMasterPasswordPrivateState struct {
+	Hash string `json:"hash"`
+}
+
 var vaultSecurityLevels map[dvls.VaultSecurityLevel]string = map[dvls.VaultSecurityLevel]string{
 	dvls.VaultSecurityLevelStandard: "standard",
 	dvls.VaultSecurityLevelHigh:     "high",
@@ -43,11 +57,18 @@ func newVaultFromResourceModel(data *VaultResourceModel) (dvls.Vault, error) {
 
 func setVaultResourceModel(vault dvls.Vault, data *VaultResourceModel) {
 	model := VaultResourceModel{
-		Id:             basetypes.NewStringValue(vault.ID),
-		Name:           basetypes.NewStringValue(vault.Name),
-		Visibility:     basetypes.NewStringValue(vaultVisibilities[vault.Visibility]),
-		SecurityLevel:  basetypes.NewStringValue(vaultSecurityLevels[vault.SecurityLevel]),
-		MasterPassword: data.MasterPassword,
+		Id:                      basetypes.NewStringValue(vault.ID),
+		Name:                    basetypes.NewStringValue(vault.Name),
+		Visibility:              basetypes.NewStringValue(vaultVisibilities[vault.Visibility]),
+		SecurityLevel:           basetypes.NewStringValue(vaultSecurityLevels[vault.SecurityLevel]),
+		MasterPassword:          data.MasterPassword,
+		MasterPasswordWOVersion: data.MasterPasswordWOVersion,
+		DeletionProtection:      data.DeletionProtection,
+	}
+
+	// Vaults imported or created before deletion_protection existed are protected by default.
+	if model.DeletionProtection.IsNull() {
+		model.DeletionProtection = basetypes.NewBoolValue(true)
 	}
 
 	if vault.Description != "" {
@@ -72,6 +93,60 @@ func setVaultDataModel(vault dvls.Vault, data *VaultDataSourceModel) {
 	*data = model
 }
 
+// getVaultMasterPassword returns the configured master password, either from master_password
+// or from the write-only master_password_wo, or nil if none is configured.
+func getVaultMasterPassword(data *VaultResourceModel, masterPasswordWO types.String) *string {
+	if !masterPasswordWO.IsNull() {
+		return masterPasswordWO.ValueStringPointer()
+	}
+
+	if !data.MasterPassword.IsNull() {
+		return data.MasterPassword.ValueStringPointer()
+	}
+
+	return nil
+}
+
+// getVaultMasterPasswordPath returns the path of the attribute the master password is configured with.
+func getVaultMasterPasswordPath(masterPasswordWO types.String) path.Path {
+	if !masterPasswordWO.IsNull() {
+		return path.Root("master_password_wo")
+	}
+
+	return path.Root("master_password")
+}
+
+// newVaultMasterPasswordPrivateState returns the private state value for password. The password is
+// pre-hashed with SHA-256 since bcrypt only supports passwords up to 72 bytes.
+func newVaultMasterPasswordPrivateState(password string) ([]byte, error) {
+	passwordHash := sha256.Sum256([]byte(password))
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(passwordHash[:])), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash master password. error: %w", err)
+	}
+
+	return json.Marshal(vaultMasterPasswordPrivateState{Hash: string(hash)})
+}
+
+// vaultMasterPasswordMatchesPrivateState returns true if password is the one stored in the private state value.
+func vaultMasterPasswordMatchesPrivateState(privateState []byte, password string) bool {
+	if len(privateState) == 0 {
+		return false
+	}
+
+	var state vaultMasterPasswordPrivateState
+
+	err := json.Unmarshal(privateState, &state)
+	if err != nil {
+		return false
+	}
+
+	passwordHash := sha256.Sum256([]byte(password))
+
+	return bcrypt.CompareHashAndPassword([]byte(state.Hash), []byte(hex.EncodeToString(passwordHash[:]))) == nil
+}
+
 func lookupMapValue[K interface {
 	int | dvls.VaultSecurityLevel | dvls.VaultVisibility
 }](lookup map[K]string, value string) (int, error) {
@@ -87,6 +87,8 @@ func (d *VaultDataSource) Configure(ctx context.Context, req datasource.Configur
 }
 
 func (d *VaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var data *VaultDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -94,7 +96,9 @@ func (d *VaultDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	logResult := logDvlsCall(ctx, "Get", "", data.Id.ValueString(), "")
 	vault, err := d.client.Vaults.Get(data.Id.ValueString())
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to read vault", err.Error())
 		return
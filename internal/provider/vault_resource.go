@@ -7,9 +7,14 @@ import (
 
 	"github.com/Devolutions/go-dvls"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -21,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &VaultResource{}
 var _ resource.ResourceWithImportState = &VaultResource{}
+var _ resource.ResourceWithIdentity = &VaultResource{}
 
 func NewVaultResource() resource.Resource {
 	return &VaultResource{}
@@ -39,6 +45,15 @@ type VaultResourceModel struct {
 	SecurityLevel  types.String `tfsdk:"security_level"`
 	Visibility     types.String `tfsdk:"visibility"`
 	MasterPassword types.String `tfsdk:"master_password"`
+
+	MasterPasswordWO        types.String `tfsdk:"master_password_wo"`
+	MasterPasswordWOVersion types.Int64  `tfsdk:"master_password_wo_version"`
+	DeletionProtection      types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// VaultResourceIdentityModel describes the resource identity.
+type VaultResourceIdentityModel struct {
+	Id types.String `tfsdk:"id"`
 }
 
 func (r *VaultResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -78,9 +93,39 @@ func (r *VaultResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Validators:  []validator.String{vaultVisibilityValidator{}},
 			},
 			"master_password": schema.StringAttribute{
-				Description: "Vault master password",
+				Description: "Vault master password. Once applied, the password is validated against the vault on each refresh and a drift is reported when it was changed outside of Terraform.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("master_password_wo"))},
+			},
+			"master_password_wo": schema.StringAttribute{
+				Description: "Vault master password, write-only. It is never stored in the state, changes are only applied when master_password_wo_version changes. Requires Terraform 1.11 or later.",
 				Optional:    true,
 				Sensitive:   true,
+				WriteOnly:   true,
+				Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("master_password"))},
+			},
+			"master_password_wo_version": schema.Int64Attribute{
+				Description: "Version of the write-only master password. Change this value to apply a new master_password_wo.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AlsoRequires(path.MatchRoot("master_password_wo"))},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Prevent the vault, and every entry it contains, from being deleted. Must be set to false and applied before the vault can be destroyed.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *VaultResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "Vault ID",
+				RequiredForImport: true,
 			},
 		},
 	}
@@ -92,21 +137,23 @@ func (r *VaultResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*dvls.Client)
+	data, ok := req.ProviderData.(*DvlsResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dvls.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DvlsResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
 }
 
 func (r *VaultResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var plan *VaultResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -121,12 +168,21 @@ func (r *VaultResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	vault.ID = uuid.NewString()
 
-	var options dvls.VaultOptions
-	if !plan.MasterPassword.IsNull() {
-		options.Password = plan.MasterPassword.ValueStringPointer()
+	var masterPasswordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("master_password_wo"), &masterPasswordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	ctx = maskDvlsLogSecrets(ctx, plan.MasterPassword.ValueString(), masterPasswordWO.ValueString())
+
+	options := dvls.VaultOptions{
+		Password: getVaultMasterPassword(plan, masterPasswordWO),
+	}
+
+	logResult := logDvlsCall(ctx, "New", "", vault.ID, "")
 	err = r.client.Vaults.New(vault, &options)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to create vault", err.Error())
 		return
@@ -134,10 +190,21 @@ func (r *VaultResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	setVaultResourceModel(vault, plan)
 
+	// Save the state before validating the master password so the vault is tracked even if it fails.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, VaultResourceIdentityModel{Id: plan.Id})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if options.Password != nil {
+		r.validateMasterPassword(ctx, vault.ID, *options.Password, getVaultMasterPasswordPath(masterPasswordWO), resp.Private, &resp.Diagnostics)
+	}
 }
 
 func (r *VaultResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var state *VaultResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -151,7 +218,11 @@ func (r *VaultResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx = maskDvlsLogSecrets(ctx, state.MasterPassword.ValueString())
+
+	logResult := logDvlsCall(ctx, "Get", "", vault.ID, "")
 	vault, err = r.client.Vaults.Get(vault.ID)
+	logResult(err)
 	if err != nil {
 		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
 			resp.State.RemoveResource(ctx)
@@ -163,22 +234,39 @@ func (r *VaultResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	setVaultResourceModel(vault, state)
 
-	valid, err := r.client.Vaults.ValidatePassword(vault.ID, state.MasterPassword.ValueString())
-	if err != nil && strings.Contains(err.Error(), "unexpected result code 0 (Error)") {
-		state.MasterPassword = basetypes.NewStringNull()
-	} else if err != nil {
-		resp.Diagnostics.AddError("unable validate vault password", err.Error())
+	privateState, diags := req.Private.GetKey(ctx, vaultMasterPasswordPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !valid {
-		state.MasterPassword = basetypes.NewStringNull()
+	// The write-only master password is not available on refresh, only master_password can be checked for
+	// drift, and only once the provider applied it, as recorded by its hash in the private state.
+	if !state.MasterPassword.IsNull() && vaultMasterPasswordMatchesPrivateState(privateState, state.MasterPassword.ValueString()) {
+		valid, err := r.checkMasterPassword(ctx, vault.ID, state.MasterPassword.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("unable to validate vault master password", err.Error())
+			return
+		}
+
+		if !valid {
+			resp.Diagnostics.AddWarning(
+				"vault master password changed outside of Terraform",
+				fmt.Sprintf("The master password of vault %s no longer matches master_password. The configured master password will be applied on the next apply.", vault.ID),
+			)
+
+			state.MasterPassword = basetypes.NewStringNull()
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, vaultMasterPasswordPrivateKey, nil)...)
+		}
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, VaultResourceIdentityModel{Id: state.Id})...)
 }
 
 func (r *VaultResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var plan *VaultResourceModel
 	var state *VaultResourceModel
 
@@ -198,21 +286,55 @@ func (r *VaultResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	var masterPasswordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("master_password_wo"), &masterPasswordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = maskDvlsLogSecrets(ctx, plan.MasterPassword.ValueString(), masterPasswordWO.ValueString())
+
+	privateState, diags := req.Private.GetKey(ctx, vaultMasterPasswordPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only send the master password when it differs from the last one applied, DVLS otherwise
+	// considers the password as changed on every update. The write-only master password is only
+	// applied when its version changes.
 	var options dvls.VaultOptions
-	if !plan.MasterPassword.IsNull() {
-		options.Password = plan.MasterPassword.ValueStringPointer()
+	password := getVaultMasterPassword(plan, masterPasswordWO)
+	if !masterPasswordWO.IsNull() && plan.MasterPasswordWOVersion.Equal(state.MasterPasswordWOVersion) {
+		password = nil
 	}
 
+	if password != nil && !vaultMasterPasswordMatchesPrivateState(privateState, *password) {
+		options.Password = password
+	}
+
+	logResult := logDvlsCall(ctx, "Update", "", vault.ID, "")
 	err = r.client.Vaults.Update(vault, &options)
+	logResult(err)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to update vault", err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, VaultResourceIdentityModel{Id: plan.Id})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if options.Password != nil {
+		r.validateMasterPassword(ctx, vault.ID, *options.Password, getVaultMasterPasswordPath(masterPasswordWO), resp.Private, &resp.Diagnostics)
+	}
 }
 
 func (r *VaultResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = newDvlsLogContext(ctx)
+
 	var state *VaultResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -220,7 +342,17 @@ func (r *VaultResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"vault is protected against deletion",
+			fmt.Sprintf("Vault %s (%s) has deletion_protection enabled. Set deletion_protection to false and apply the change before destroying it.", state.Name.ValueString(), state.Id.ValueString()),
+		)
+		return
+	}
+
+	logResult := logDvlsCall(ctx, "Delete", "", state.Id.ValueString(), "")
 	err := r.client.Vaults.Delete(state.Id.ValueString())
+	logResult(err)
 	if err != nil {
 		if strings.Contains(err.Error(), dvls.SaveResultNotFound.String()) {
 			resp.State.RemoveResource(ctx)
@@ -231,6 +363,42 @@ func (r *VaultResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// validateMasterPassword ensures password was applied to the vault and stores its hash in the private state.
+// Errors are reported on passwordPath, the attribute the password was configured with.
+func (r *VaultResource) validateMasterPassword(ctx context.Context, vaultId string, password string, passwordPath path.Path, private privateStateSetter, diags *diag.Diagnostics) {
+	valid, err := r.checkMasterPassword(ctx, vaultId, password)
+	if err != nil {
+		diags.AddError("unable to validate vault master password", err.Error())
+		return
+	}
+
+	if !valid {
+		diags.AddAttributeError(passwordPath, "vault master password was not applied", fmt.Sprintf("the master password of vault %s does not match the configured master password after being set", vaultId))
+		return
+	}
+
+	privateState, err := newVaultMasterPasswordPrivateState(password)
+	if err != nil {
+		diags.AddError("unable to save vault master password state", err.Error())
+		return
+	}
+
+	diags.Append(private.SetKey(ctx, vaultMasterPasswordPrivateKey, privateState)...)
+}
+
+// checkMasterPassword returns true if password is the master password of the vault.
+func (r *VaultResource) checkMasterPassword(ctx context.Context, vaultId string, password string) (bool, error) {
+	logResult := logDvlsCall(ctx, "ValidatePassword", "", vaultId, "")
+	valid, err := r.client.Vaults.ValidatePassword(vaultId, password)
+	logResult(err)
+
+	return valid, err
+}
+
 func (r *VaultResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
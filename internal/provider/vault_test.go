@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVaultMasterPasswordPrivateState(t *testing.T) {
+	// Longer than the 72 bytes supported by bcrypt, to ensure the whole password is compared.
+	password := strings.Repeat("a", 80)
+
+	privateState, err := newVaultMasterPasswordPrivateState(password)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := map[string]struct {
+		privateState []byte
+		password     string
+		expected     bool
+	}{
+		"same password":      {privateState: privateState, password: password, expected: true},
+		"different password": {privateState: privateState, password: strings.Repeat("a", 79) + "b", expected: false},
+		"empty private data": {privateState: nil, password: password, expected: false},
+		"invalid json":       {privateState: []byte("not json"), password: password, expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if matches := vaultMasterPasswordMatchesPrivateState(test.privateState, test.password); matches != test.expected {
+				t.Errorf("vaultMasterPasswordMatchesPrivateState = %t, expected %t", matches, test.expected)
+			}
+		})
+	}
+}
+
+func TestGetVaultMasterPasswordPath(t *testing.T) {
+	if p := getVaultMasterPasswordPath(types.StringValue("password")); !p.Equal(path.Root("master_password_wo")) {
+		t.Errorf("unexpected path %s for a write-only master password", p)
+	}
+
+	if p := getVaultMasterPasswordPath(types.StringNull()); !p.Equal(path.Root("master_password")) {
+		t.Errorf("unexpected path %s for master_password", p)
+	}
+}